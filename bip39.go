@@ -0,0 +1,121 @@
+package passit
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+type bip39Generator struct {
+	words int
+}
+
+// BIP39Mnemonic returns a Generator that generates a BIP-39 mnemonic of the given
+// number of words from the BIP-39 English wordlist. The words are separated by a
+// single space.
+//
+// words must be 12, 15, 18, 21 or 24, corresponding to 128, 160, 192, 224 and 256
+// bits of entropy respectively. The entropy is read directly from r and is
+// followed by a checksum taken from the SHA-256 hash of the entropy, as described
+// in the BIP-39 specification. This means that, given the same entropy, the
+// generated mnemonic is identical to that produced by any other BIP-39
+// implementation.
+//
+// The returned mnemonic can be validated with ValidateBIP39Mnemonic.
+func BIP39Mnemonic(words int) Generator {
+	switch words {
+	case 12, 15, 18, 21, 24:
+		return &bip39Generator{words}
+	default:
+		panic("passit: BIP-39 mnemonic must be 12, 15, 18, 21 or 24 words")
+	}
+}
+
+func (bg *bip39Generator) Password(r io.Reader) (string, error) {
+	// Each word encodes 11 bits and for every 32 bits of entropy there is 1 bit
+	// of checksum, so there are 4 bytes of entropy for every 3 words.
+	entropy := make([]byte, bg.words/3*4)
+	if _, err := io.ReadFull(r, entropy); err != nil {
+		return "", wrapReadError(err)
+	}
+
+	return bip39Encode(entropy), nil
+}
+
+func bip39Wordlist() []string {
+	return BIP39English.(*embeddedGenerator).wordlist()
+}
+
+func bip39Encode(entropy []byte) string {
+	list := bip39Wordlist()
+
+	sum := sha256.Sum256(entropy)
+	data := append(slices.Clip(entropy), sum[0])
+
+	words := len(entropy) * 8 * 33 / 32 / 11
+	parts := make([]string, words)
+	for i := range parts {
+		parts[i] = list[bip39Index(data, i)]
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// bip39Index returns the i-th 11-bit big-endian value in data.
+func bip39Index(data []byte, i int) int {
+	var idx int
+	for bit := i * 11; bit < (i+1)*11; bit++ {
+		idx = idx<<1 | int(data[bit/8]>>(7-bit%8)&1)
+	}
+
+	return idx
+}
+
+// ValidateBIP39Mnemonic returns an error if mnemonic is not a valid BIP-39
+// mnemonic using the BIP-39 English wordlist.
+//
+// The mnemonic must contain 12, 15, 18, 21 or 24 words separated by whitespace,
+// each of which must be in the wordlist, and the checksum must match.
+func ValidateBIP39Mnemonic(mnemonic string) error {
+	_, err := bip39Decode(mnemonic)
+	return err
+}
+
+func bip39Decode(mnemonic string) ([]byte, error) {
+	parts := strings.Fields(mnemonic)
+	switch len(parts) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("passit: BIP-39 mnemonic has %d words, must be 12, 15, 18, 21 or 24", len(parts))
+	}
+
+	list := bip39Wordlist()
+
+	// The data is the entropy followed by up to 8 bits of checksum.
+	data := make([]byte, len(parts)/3*4+1)
+	for i, word := range parts {
+		idx, ok := slices.BinarySearch(list, word)
+		if !ok {
+			return nil, fmt.Errorf("passit: BIP-39 mnemonic word %d %q is not in the wordlist", i+1, word)
+		}
+
+		for j := range 11 {
+			bit := i*11 + j
+			data[bit/8] |= byte(idx>>(10-j)&1) << (7 - bit%8)
+		}
+	}
+
+	entropy, checksum := data[:len(data)-1], data[len(data)-1]
+	checksumBits := len(entropy) / 4
+	mask := byte(0xff) << (8 - checksumBits)
+
+	sum := sha256.Sum256(entropy)
+	if sum[0]&mask != checksum {
+		return nil, errors.New("passit: BIP-39 mnemonic checksum mismatch")
+	}
+
+	return entropy, nil
+}
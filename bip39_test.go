@@ -0,0 +1,132 @@
+package passit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBIP39Mnemonic(t *testing.T) {
+	// These test vectors are from the BIP-39 reference implementation:
+	// https://github.com/trezor/python-mnemonic/blob/master/vectors.json.
+	for _, tc := range []struct{ entropy, mnemonic string }{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"80808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{"ffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"},
+		{"000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will"},
+		{"808080808080808080808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo when"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title"},
+		{"8080808080808080808080808080808080808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+		{"77c2b00716cec7213839159e404db50d", "jelly better achieve collect unaware mountain thought cargo oxygen act hood bridge"},
+		{"b63a9c59a6e641f288ebc103017f1da9f8290b3da6bdef7b", "renew stay biology evidence goat welcome casual join adapt armor shuffle fault little machine walk stumble urge swap"},
+		{"3e141609b97933b66a060dcddc71fad1d91677db872031e85f4c015c5e7e8982", "dignity pass list indicate nasty swamp pool script soccer toe leaf photo multiply desk host tomato cradle drill spread actor shine dismiss champion exotic"},
+		{"0460ef47585604c5660618db2e6a7e7f", "afford alter spike radar gate glance object seek swamp infant panel yellow"},
+		{"72f60ebac5dd8add8d2a25a797102c3ce21bc029c200076f", "indicate race push merry suffer human cruise dwarf pole review arch keep canvas theme poem divorce alter left"},
+		{"2c85efc7f24ee4573d2b81a6ec66cee209b2dcbd09d8eddc51e0215b0b68e416", "clutch control vehicle tonight unusual clog visa ice plunge glimpse recipe series open hour vintage deposit universe tip job dress radar refuse motion taste"},
+		{"eaebabb2383351fd31d703840b32e9e2", "turtle front uncle idea crush write shrug there lottery flower risk shell"},
+		{"7ac45cfe7722ee6c7ba84fbc2d5bd61b45cb2fe5eb65aa78", "kiss carry display unusual confirm curtain upgrade antique rotate hello void custom frequent obey nut hole price segment"},
+		{"4fa1a8bc3e6d80ee1316050e862c1812031493212b7ec3f3bb1b08f168cabeef", "exile ask congress lamp submit jacket era scheme attend cousin alcohol catch course end lucky hurt sentence oven short ball bird grab wing top"},
+		{"18ab19a9f54a9274f03e5209a2ac8a91", "board flee heavy tunnel powder denial science ski answer betray cargo cat"},
+		{"18a2e1d81b8ecfb2a333adcb0c17a5b9eb76cc5d05db91a4", "board blade invite damage undo sun mimic interest slam gaze truly inherit resist great inject rocket museum chief"},
+		{"15da872c95a13dd738fbf50e427583ad61f18fd99f628c417a61cf8343c90419", "beyond stage sleep clip because twist token leaf atom beauty genius food business side grid unable middle armed observe pair crouch tonight away coconut"},
+	} {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+
+		pass, err := BIP39Mnemonic(len(entropy) * 3 / 4).Password(bytes.NewReader(entropy))
+		if assert.NoErrorf(t, err, "BIP39Mnemonic: %s", tc.entropy) {
+			assert.Equalf(t, tc.mnemonic, pass, "BIP39Mnemonic: %s", tc.entropy)
+		}
+
+		assert.NoErrorf(t, ValidateBIP39Mnemonic(tc.mnemonic), "ValidateBIP39Mnemonic(%q)", tc.mnemonic)
+
+		got, err := bip39Decode(tc.mnemonic)
+		if assert.NoErrorf(t, err, "bip39Decode(%q)", tc.mnemonic) {
+			assert.Equalf(t, entropy, got, "bip39Decode(%q)", tc.mnemonic)
+		}
+	}
+
+	pass, err := BIP39Mnemonic(12).Password(newTestRand())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "group engage vivid tenant people build cancel palm flush faculty approve frequent", pass)
+	}
+
+	tr := newTestRand()
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		for range 20 {
+			pass, err := BIP39Mnemonic(words).Password(tr)
+			require.NoError(t, err)
+
+			assert.NoErrorf(t, ValidateBIP39Mnemonic(pass), "ValidateBIP39Mnemonic(%q)", pass)
+		}
+	}
+
+	for _, words := range []int{-1, 0, 1, 11, 13, 25} {
+		assert.PanicsWithValue(t, "passit: BIP-39 mnemonic must be 12, 15, 18, 21 or 24 words", func() {
+			BIP39Mnemonic(words)
+		})
+	}
+
+	_, err = BIP39Mnemonic(12).Password(bytes.NewReader(make([]byte, 15)))
+	assert.ErrorContains(t, err, "passit: failed to read entropy: ")
+}
+
+func TestValidateBIP39Mnemonic(t *testing.T) {
+	for _, tc := range []struct{ mnemonic, err string }{
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "passit: BIP-39 mnemonic has 11 words, must be 12, 15, 18, 21 or 24"},
+		{"legal winner thank year wave sausage worth useful legal winner thank yellow yellow", "passit: BIP-39 mnemonic has 13 words, must be 12, 15, 18, 21 or 24"},
+		{"letter advice cage absurd amount doctor acoustic avoid letter advice caged above", `passit: BIP-39 mnemonic word 11 "caged" is not in the wordlist`},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong", `passit: BIP-39 mnemonic word 11 "zoo," is not in the wordlist`},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo why", `passit: BIP-39 mnemonic word 18 "why" is not in the wordlist`},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo", "passit: BIP-39 mnemonic checksum mismatch"},
+		{"jello better achieve collect unaware mountain thought cargo oxygen act hood bridge", `passit: BIP-39 mnemonic word 1 "jello" is not in the wordlist`},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon letter", "passit: BIP-39 mnemonic checksum mismatch"},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "passit: BIP-39 mnemonic checksum mismatch"},
+	} {
+		assert.EqualErrorf(t, ValidateBIP39Mnemonic(tc.mnemonic), tc.err, "ValidateBIP39Mnemonic(%q)", tc.mnemonic)
+	}
+
+	// These invalid mnemonics are taken from github.com/tyler-smith/go-bip39.
+	for _, mnemonic := range []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow yellow",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice caged above",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will will will",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always.",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo why",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art art",
+		"legal winner thank year wave sausage worth useful legal winner thanks year wave worth useful legal winner thank year wave sausage worth title",
+		"letter advice cage absurd amount doctor acoustic avoid letters advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo voted",
+		"jello better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+		"renew, stay, biology, evidence, goat, welcome, casual, join, adapt, armor, shuffle, fault, little, machine, walk, stumble, urge, swap",
+		"dignity pass list indicate nasty",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon letter",
+	} {
+		assert.Errorf(t, ValidateBIP39Mnemonic(mnemonic), "ValidateBIP39Mnemonic(%q)", mnemonic)
+	}
+}
+
+func TestBIP39Wordlist(t *testing.T) {
+	list := bip39Wordlist()
+	assert.Len(t, list, 2048)
+	assert.True(t, slices.IsSorted(list), "BIP-39 English wordlist must be sorted")
+
+	prefixes := make(map[string]bool, len(list))
+	for _, word := range list {
+		prefix := word[:min(4, len(word))]
+		assert.Falsef(t, prefixes[prefix], "duplicate prefix %q", prefix)
+		prefixes[prefix] = true
+	}
+}
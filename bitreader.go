@@ -0,0 +1,151 @@
+package passit
+
+import (
+	"bufio"
+	"io"
+)
+
+// Determinism identifies a mapping from random input to generated passwords. Two
+// readers with the same Determinism that return the same input produce the same
+// passwords from the same Generator.
+type Determinism string
+
+const (
+	// DeterminismBytes1 is the mapping used when r is a plain io.Reader. Each
+	// random integer is read as a little-endian value of the fewest whole bytes
+	// that can hold it, rejecting values that would introduce bias.
+	//
+	// Earlier releases of passit, from before Determinism was introduced, read
+	// integers the same way, but aren't covered by DeterminismBytes1: the
+	// Orchard Street wordlists then included an extra empty word, so generators
+	// built on them produced different passwords, see CHANGELOG.md.
+	DeterminismBytes1 Determinism = "passit/bytes/1"
+
+	// DeterminismBits1 is the mapping used by a BitReader.
+	DeterminismBits1 Determinism = "passit/bits/1"
+
+	// DeterminismSource1 is the mapping used when r implements Source. Each
+	// random integer is drawn with a single call to IntN, so the input is the
+	// sequence of values returned by the Source rather than bytes.
+	DeterminismSource1 Determinism = "passit/source/1"
+)
+
+// DeterminismOf returns the Determinism that generators use for r.
+func DeterminismOf(r io.Reader) Determinism {
+	switch r.(type) {
+	case *BitReader:
+		return DeterminismBits1
+	case Source:
+		return DeterminismSource1
+	default:
+		return DeterminismBytes1
+	}
+}
+
+// BitReader is an io.Reader that makes generators consume as little of the
+// underlying reader as possible. It is created by NewBitReader.
+//
+// By default, every random integer is read as whole bytes, so selecting a word
+// from EFFLargeWordlist reads 16 bits for 12.9 bits of entropy, and a Digit reads
+// 8 bits for 3.3. A BitReader instead keeps a uniformly distributed value that
+// carries the unused entropy from one draw to the next, reading more bytes only
+// when needed. Values that must be rejected to avoid bias are also carried over.
+// Over many draws, a BitReader reads close to the entropy of the password.
+//
+// This is useful where randomness is scarce, like physical dice or a hardware
+// security module with limited throughput. The generated passwords follow
+// DeterminismBits1 and differ from those generated by reading the same bytes
+// directly. Generators that consume raw bytes, like the encoding generators and
+// BIP39Mnemonic, read them directly from the underlying reader.
+//
+// A BitReader is not safe for concurrent use.
+type BitReader struct {
+	r  io.Reader
+	br io.ByteReader
+
+	// v is uniformly distributed in [0,m).
+	v, m uint64
+}
+
+// NewBitReader returns a BitReader that reads from r. If r doesn't implement
+// io.ByteReader, it is wrapped in a bufio.Reader.
+func NewBitReader(r io.Reader) *BitReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		b := bufio.NewReader(r)
+		r, br = b, b
+	}
+
+	return &BitReader{r: r, br: br, m: 1}
+}
+
+// Read implements io.Reader. It reads directly from the underlying reader.
+func (b *BitReader) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
+
+// ReadByte implements io.ByteReader. It reads directly from the underlying reader.
+func (b *BitReader) ReadByte() (byte, error) {
+	return b.br.ReadByte()
+}
+
+func (b *BitReader) sampleIntN(n int) (int, error) {
+	v, err := b.sampleUint64n(uint64(n))
+	return int(v), err
+}
+
+func (b *BitReader) sampleUint64() (uint64, error) {
+	hi, err := b.sampleUint64n(1 << 32)
+	if err != nil {
+		return 0, err
+	}
+
+	lo, err := b.sampleUint64n(1 << 32)
+	return hi<<32 | lo, err
+}
+
+// sampleUint64n returns a uniform random value in [0,n).
+func (b *BitReader) sampleUint64n(n uint64) (uint64, error) {
+	if n > 1<<32 {
+		// Keeping v and m large enough for n would overflow, so draw a full
+		// 64-bit value and reject the 2^64 mod n smallest values.
+		for {
+			v, err := b.sampleUint64()
+			if err != nil {
+				return 0, err
+			}
+			if v >= -n%n {
+				return v % n, nil
+			}
+		}
+	}
+
+	for {
+		// Keep m at least 256 times n so that fewer than 1 in 256 draws are
+		// rejected. m < n<<8 <= 2^40 here, so shifting in another byte can't
+		// overflow.
+		for b.m < n<<8 {
+			c, err := b.br.ReadByte()
+			if err != nil {
+				return 0, wrapReadError(err)
+			}
+
+			b.v = b.v<<8 | uint64(c)
+			b.m <<= 8
+		}
+
+		// If v is below the largest multiple of n not greater than m, then
+		// v%n is uniform in [0,n) and v/n is uniform in [0,m/n). Otherwise
+		// v-limit is uniform in [0,m%n).
+		limit := b.m - b.m%n
+		if b.v < limit {
+			v := b.v % n
+			b.v /= n
+			b.m /= n
+			return v, nil
+		}
+
+		b.v -= limit
+		b.m -= limit
+	}
+}
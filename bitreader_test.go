@@ -0,0 +1,113 @@
+package passit
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitReader(t *testing.T) {
+	lr, err := RepeatLength(EFFLargeWordlist, "-", 8, 40, 48)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		expect string
+	}{
+		{"Digit", Repeat(Digit, "", 12), "543065717092"},
+		{"EFFLargeWordlist", Repeat(EFFLargeWordlist, "-", 6), "flanked-blinker-stalemate-countdown-scabbed-serve"},
+		{"Emoji15", Repeat(Emoji15, "", 4), "🥭🧦💂🏾\u200d♂️📘"},
+		{"HexLower", HexLower(8), "66e94bd4ef8a2c3b"},
+		{"SpectreLong", SpectreLong, "MariPuviPasr9$"},
+		{"RepeatLength", lr, "blade-duo-morally-action-emerald-widow-gray-kick"},
+	} {
+		pass, err := tc.gen.Password(NewBitReader(newTestRand()))
+		if !assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			continue
+		}
+		assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+	}
+}
+
+func TestBitReaderConsumption(t *testing.T) {
+	gen := Repeat(Digit, "", 1000)
+
+	bytesRead := func(r func(io.Reader) io.Reader) int64 {
+		cr := NewCountingReader(newTestRand())
+		_, err := gen.Password(r(cr))
+		require.NoError(t, err)
+		return cr.BytesRead()
+	}
+
+	// Reading whole bytes uses at least one byte per digit, while a BitReader
+	// uses close to log2(10) bits per digit.
+	direct := bytesRead(func(r io.Reader) io.Reader { return r })
+	bits := bytesRead(func(r io.Reader) io.Reader { return NewBitReader(r) })
+	assert.GreaterOrEqual(t, direct, int64(1000))
+	assert.Less(t, bits, int64(1000*3322/8000+8))
+}
+
+func TestBitReaderUniform(t *testing.T) {
+	// A draw with n <= 256 reads exactly two bytes, so for n that divides 65536
+	// each output must occur equally often across all two byte inputs.
+	for _, n := range []int{2, 4, 16, 256} {
+		counts := make(map[int]int)
+		for b := range 1 << 16 {
+			v, err := NewBitReader(bytes.NewReader([]byte{byte(b >> 8), byte(b)})).sampleIntN(n)
+			require.NoError(t, err)
+			counts[v]++
+		}
+		for v := range n {
+			assert.Equalf(t, 1<<16/n, counts[v], "n=%d v=%d", n, v)
+		}
+	}
+
+	// For other n, rejected values are carried into the next draw, so checking
+	// the distribution over a large random sample is sufficient.
+	br := NewBitReader(newTestRand())
+	counts := make([]int, 10)
+	for range 100000 {
+		v, err := br.sampleIntN(10)
+		require.NoError(t, err)
+		counts[v]++
+	}
+	for v, c := range counts {
+		assert.InDeltaf(t, 10000, c, 500, "v=%d", v)
+	}
+}
+
+func TestBitReaderLarge(t *testing.T) {
+	br := NewBitReader(newTestRand())
+	for _, n := range []uint64{1<<32 + 1, 1 << 63, ^uint64(0)} {
+		for range 100 {
+			v, err := br.sampleUint64n(n)
+			require.NoError(t, err)
+			assert.Less(t, v, n)
+		}
+	}
+
+	n := new(big.Int).Lsh(big.NewInt(3), 64)
+	for range 100 {
+		v, err := readBigIntN(br, n)
+		require.NoError(t, err)
+		assert.Equal(t, -1, v.Cmp(n))
+		assert.GreaterOrEqual(t, v.Sign(), 0)
+	}
+}
+
+func TestBitReaderError(t *testing.T) {
+	_, err := Repeat(Digit, "", 4).Password(NewBitReader(bytes.NewReader([]byte{0xff})))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.ErrorContains(t, err, "passit: failed to read entropy: ")
+}
+
+func TestDeterminismOf(t *testing.T) {
+	assert.Equal(t, DeterminismBytes1, DeterminismOf(newTestRand()))
+	assert.Equal(t, DeterminismBits1, DeterminismOf(NewBitReader(newTestRand())))
+	assert.Equal(t, DeterminismSource1, DeterminismOf(NewChaCha8Source([32]byte{})))
+}
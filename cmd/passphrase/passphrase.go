@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"go.tmthrgd.dev/passit"
-	"go.tmthrgd.dev/passit/internal/wordlists"
+	"go.tmthrgd.dev/passit/wordlists"
 	"golang.org/x/text/language"
 )
 
@@ -37,8 +39,8 @@ func main() {
 func main1() error {
 	list := flag.String("l", "orchard:long",
 		"the wordlist to use; valid options are:\n"+
-			"orchard:medium, orchard:long, orchard:alpha, "+
-			"orchard:qwerty, eff:large, eff:short1 and eff:short2")
+			strings.Join(wordlists.Names(), ", "))
+	showLists := flag.Bool("L", false, "list the available wordlists and exit")
 	words := flag.Int("n", 6, "the number of words in the generated passphrase")
 	sep := flag.String("s", " ", "the separator to use between words")
 	titleCase := flag.Bool("t", false, "generate a title case passphrase")
@@ -46,11 +48,22 @@ func main1() error {
 	count := flag.Int("c", 1, "the number of passwords to generate, one per line")
 	flag.Parse()
 
-	gen := wordlists.NameToGenerator(*list)
+	if *showLists {
+		return printWordlists()
+	}
+
+	gen := wordlists.Generator(*list)
 	if gen == nil {
 		return errors.New("passphrase: invalid wordlist specified")
 	}
 
+	// The case transforms hide the wordlist, so check it before wrapping it.
+	if *words > 1 {
+		if err := passit.CheckDecodable(gen, *sep); err != nil {
+			return err
+		}
+	}
+
 	if *upperCase {
 		gen = passit.UpperCase(gen)
 	} else if *titleCase {
@@ -70,3 +83,14 @@ func main1() error {
 
 	return nil
 }
+
+func printWordlists() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tALIASES\tWORDS\tBITS/WORD\tLICENSE\tDESCRIPTION")
+	for _, wl := range wordlists.All() {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.3f\t%s\t%s\n",
+			wl.Name, strings.Join(wl.Aliases, ", "), wl.Size,
+			wl.BitsPerWord, wl.License, wl.Description)
+	}
+	return w.Flush()
+}
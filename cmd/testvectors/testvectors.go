@@ -0,0 +1,53 @@
+// Command testvectors regenerates the JSON test vectors published in the
+// testvectors package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.tmthrgd.dev/passit/testvectors"
+)
+
+func init() {
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintln(out, "testvectors is a tool that regenerates the JSON test vectors")
+		fmt.Fprintln(out, "used to check other implementations of passit.")
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	if err := main1(); err != nil {
+		log.SetFlags(0)
+		log.Fatal(err)
+	}
+}
+
+func main1() error {
+	out := flag.String("o", "", "the file to write the test vectors to; if empty, they are written to stdout")
+	flag.Parse()
+
+	f, err := testvectors.Generate()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	return os.WriteFile(*out, buf.Bytes(), 0o644)
+}
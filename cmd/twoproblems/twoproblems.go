@@ -17,7 +17,7 @@ import (
 	"strings"
 
 	"go.tmthrgd.dev/passit"
-	"go.tmthrgd.dev/passit/internal/wordlists"
+	"go.tmthrgd.dev/passit/wordlists"
 	"golang.org/x/text/language"
 )
 
@@ -71,17 +71,17 @@ func main1() error {
 func wordlist(sr *syntax.Regexp) (passit.Generator, error) {
 	switch sr.Sub[0].Op {
 	case syntax.OpEmptyMatch:
-		return passit.OrchardStreetLong, nil
+		return passit.OrchardStreetLongV2, nil
 	case syntax.OpLiteral:
 		p, err := parseWordlistParams(string(sr.Sub[0].Rune))
 		if err != nil {
 			return nil, fmt.Errorf("twoproblems: failed to parse word parameters: %w", err)
 		}
 
-		gen := passit.OrchardStreetLong
+		gen := passit.OrchardStreetLongV2
 		if v, ok := p["list"]; ok {
 			name := strings.ToLower(v)
-			gen = wordlists.NameToGenerator(name)
+			gen = wordlists.Generator(name)
 			if gen == nil {
 				return nil, fmt.Errorf("twoproblems: unsupported wordlist %q", name)
 			}
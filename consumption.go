@@ -0,0 +1,305 @@
+package passit
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/exp/utf8string"
+)
+
+// CountingReader is an io.Reader that counts the bytes read from an underlying
+// reader. It is created by NewCountingReader.
+//
+// CountingReader implements io.ByteReader, reading a single byte at a time from
+// the underlying reader if it doesn't, so that the count never includes bytes
+// that were buffered but not used. Generators read the same bytes from a
+// CountingReader as they would from the underlying reader, so wrapping a plain
+// io.Reader doesn't change the generated passwords.
+//
+// To count the bytes read by a BitReader, wrap the reader passed to NewBitReader.
+// A CountingReader hides any Source implemented by the underlying reader, so
+// passwords generated through it follow DeterminismBytes1.
+type CountingReader struct {
+	r  io.Reader
+	br io.ByteReader
+	n  int64
+}
+
+// NewCountingReader returns a CountingReader that reads from r.
+func NewCountingReader(r io.Reader) *CountingReader {
+	br, _ := r.(io.ByteReader)
+	return &CountingReader{r: r, br: br}
+}
+
+// Read implements io.Reader.
+func (cr *CountingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// ReadByte implements io.ByteReader.
+func (cr *CountingReader) ReadByte() (byte, error) {
+	if cr.br != nil {
+		b, err := cr.br.ReadByte()
+		if err == nil {
+			cr.n++
+		}
+		return b, err
+	}
+
+	var buf [1]byte
+	_, err := io.ReadFull(cr, buf[:])
+	return buf[0], err
+}
+
+// BytesRead returns the number of bytes read so far.
+func (cr *CountingReader) BytesRead() int64 {
+	return cr.n
+}
+
+// Consumption describes the number of bytes a Generator reads to generate a
+// single password. It is returned by EstimateConsumption.
+type Consumption struct {
+	// Min is the fewest bytes that may be read.
+	Min int
+
+	// Expected is the mean number of bytes read. It is NaN if it depends on how
+	// often the condition of a RejectionSample or ConstrainLength generator is
+	// satisfied; measure it with a CountingReader instead.
+	Expected float64
+
+	// Max is the most bytes that may be read, or -1 if there is no upper bound.
+	// A Generator may read an unbounded number of bytes when a value must be
+	// rejected to avoid bias, which happens whenever a random choice isn't
+	// between a power of two number of options.
+	Max int
+}
+
+// Bounded reports whether there is an upper bound on the number of bytes read.
+func (c Consumption) Bounded() bool {
+	return c.Max >= 0
+}
+
+func (c Consumption) add(d Consumption) Consumption {
+	c.Min += d.Min
+	c.Expected += d.Expected
+	if c.Max < 0 || d.Max < 0 {
+		c.Max = -1
+	} else {
+		c.Max += d.Max
+	}
+	return c
+}
+
+func (c Consumption) scale(k int) Consumption {
+	switch {
+	case k == 0:
+		return Consumption{}
+	case c.Max >= 0:
+		c.Max *= k
+	}
+	c.Min *= k
+	c.Expected *= float64(k)
+	return c
+}
+
+// EstimateConsumption returns the number of bytes gen reads from a plain io.Reader
+// to generate a password, following DeterminismBytes1. It is calculated from the
+// structure of gen without generating any passwords, and accounts for values that
+// are rejected to avoid bias. It can be used to size deterministic streams or the
+// number of dice rolls needed.
+//
+// The result doesn't apply to a BitReader, which reads fewer bytes, or to a
+// Source, which isn't read as bytes.
+//
+// gen must be one of:
+//   - a package level Generator or SpectreTemplate, like Digit, EFFLargeWordlist,
+//     Emoji15 or SpectreLong;
+//   - a Generator returned by String, FromCharset, FromRangeTable, FromSlice,
+//     HexLower, HexUpper, Base32, Base32Hex, Base64, Base64URL, Ascii85,
+//     BIP39Mnemonic, FilterEmojiGroups or ExcludeEmojiFeatures;
+//   - a *Wordlist, *EmojiSet or *LengthRepeat, or a *Sentence whose slots are
+//     all one of these;
+//   - a Generator returned by Join, Repeat, RepeatGen, RandomRepeat, Alternate,
+//     RejectionSample, ConstrainLength, Transform, LowerCase, UpperCase or
+//     TitleCase, where every Generator passed to it is also one of these.
+//
+// It returns an error for any other Generator, including those returned by
+// ParseRegexp and RegexpParser and any Generator implemented outside this
+// package.
+func EstimateConsumption(gen Generator) (Consumption, error) {
+	switch gen := gen.(type) {
+	case fixedString:
+		return Consumption{}, nil
+	case *asciiGenerator:
+		return intNConsumption(len(gen.s)), nil
+	case *runeGenerator:
+		return intNConsumption((*utf8string.String)(gen).RuneCount()), nil
+	case *unicodeGenerator:
+		return intNConsumption(gen.runes), nil
+	case *embeddedGenerator:
+		return intNConsumption(gen.len()), nil
+	case wordlistGenerator:
+		return intNConsumption(len(gen.wordlist())), nil
+	case *encodingGenerator:
+		return Consumption{gen.count, float64(gen.count), gen.count}, nil
+	case *bip39Generator:
+		n := gen.words / 3 * 4
+		return Consumption{n, float64(n), n}, nil
+	case SpectreTemplate:
+		return spectreConsumption(gen), nil
+	case *LengthRepeat:
+		return bigIntNConsumption(gen.total), nil
+	case *transformGenerator:
+		return EstimateConsumption(gen.gen)
+	case *rejectionGenerator:
+		c, err := EstimateConsumption(gen.gen)
+		if err != nil {
+			return Consumption{}, err
+		}
+		return Consumption{c.Min, math.NaN(), -1}, nil
+	case *concatGenerator:
+		return sumConsumption(gen.gens)
+	case *Sentence:
+		var c Consumption
+		for _, part := range gen.parts {
+			if part.gen == nil {
+				continue
+			}
+
+			pc, err := EstimateConsumption(part.gen)
+			if err != nil {
+				return Consumption{}, err
+			}
+			c = c.add(pc)
+		}
+		return c, nil
+	case *repeatGenerator:
+		c, err := EstimateConsumption(gen.gen)
+		return c.scale(gen.count), err
+	case *repeatGenGenerator:
+		c, err := EstimateConsumption(gen.gen)
+		if err != nil {
+			return Consumption{}, err
+		}
+		sc, err := EstimateConsumption(gen.sep)
+		return c.scale(gen.count).add(sc.scale(gen.count - 1)), err
+	case *randomRepeatGenerator:
+		c, err := EstimateConsumption(gen.gen)
+		if err != nil {
+			return Consumption{}, err
+		}
+
+		// The mean of count*c over each count in [min,min+n) is the mean count
+		// times c.
+		rc := c.scale(gen.min + gen.n - 1)
+		rc.Min = c.Min * gen.min
+		rc.Expected = c.Expected * (float64(gen.min) + float64(gen.n-1)/2)
+		return intNConsumption(gen.n).add(rc), nil
+	case *alternateGenerator:
+		c, err := alternateConsumption(gen.gens)
+		if err != nil {
+			return Consumption{}, err
+		}
+		return intNConsumption(len(gen.gens)).add(c), nil
+	default:
+		return Consumption{}, fmt.Errorf("passit: cannot estimate consumption of %T", gen)
+	}
+}
+
+// sumConsumption returns the consumption of calling each of gens in turn.
+func sumConsumption(gens []Generator) (Consumption, error) {
+	var c Consumption
+	for _, gen := range gens {
+		gc, err := EstimateConsumption(gen)
+		if err != nil {
+			return Consumption{}, err
+		}
+		c = c.add(gc)
+	}
+	return c, nil
+}
+
+// alternateConsumption returns the consumption of calling one of gens chosen
+// uniformly at random.
+func alternateConsumption(gens []Generator) (Consumption, error) {
+	cs := make([]Consumption, len(gens))
+	for i, gen := range gens {
+		c, err := EstimateConsumption(gen)
+		if err != nil {
+			return Consumption{}, err
+		}
+		cs[i] = c
+	}
+	return oneOfConsumption(cs), nil
+}
+
+func spectreConsumption(st SpectreTemplate) Consumption {
+	templates := strings.Split(string(st), ":")
+	cs := make([]Consumption, len(templates))
+	for i, template := range templates {
+		for _, c := range []byte(template) {
+			cs[i] = cs[i].add(intNConsumption(len(spectreChars[c])))
+		}
+	}
+	return intNConsumption(len(templates)).add(oneOfConsumption(cs))
+}
+
+// oneOfConsumption returns the consumption of one of cs chosen uniformly at
+// random.
+func oneOfConsumption(cs []Consumption) Consumption {
+	c := Consumption{Min: math.MaxInt}
+	for _, oc := range cs {
+		c.Min = min(c.Min, oc.Min)
+		c.Expected += oc.Expected / float64(len(cs))
+		if c.Max >= 0 && oc.Max >= 0 {
+			c.Max = max(c.Max, oc.Max)
+		} else {
+			c.Max = -1
+		}
+	}
+	return c
+}
+
+// intNConsumption returns the consumption of readIntN.
+func intNConsumption(n int) Consumption {
+	if n <= 1 {
+		return Consumption{}
+	}
+
+	byteLen := (bits.Len(uint(n)) + 7) / 8
+	return uniformConsumption(big.NewInt(int64(n)), byteLen)
+}
+
+// bigIntNConsumption returns the consumption of readBigIntN.
+func bigIntNConsumption(n *big.Int) Consumption {
+	if n.IsInt64() && n.Int64() <= 1 {
+		return Consumption{}
+	}
+
+	return uniformConsumption(n, (n.BitLen()+7)/8)
+}
+
+// uniformConsumption returns the consumption of repeatedly reading byteLen bytes
+// until the value read is below the largest multiple of n not greater than
+// 2^(8*byteLen), as readUint64n and readBigIntN do.
+func uniformConsumption(n *big.Int, byteLen int) Consumption {
+	rng := new(big.Int).Lsh(big.NewInt(1), uint(byteLen*8))
+	rem := new(big.Int).Mod(rng, n)
+	if rem.Sign() == 0 {
+		return Consumption{byteLen, float64(byteLen), byteLen}
+	}
+
+	// The number of attempts is geometrically distributed with a probability of
+	// success of (rng-rem)/rng.
+	p, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Sub(rng, rem)),
+		new(big.Float).SetInt(rng),
+	).Float64()
+	return Consumption{byteLen, float64(byteLen) / p, -1}
+}
@@ -0,0 +1,168 @@
+package passit
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestCountingReader(t *testing.T) {
+	for _, r := range []func() io.Reader{
+		newTestRand,
+		func() io.Reader { return iotest.OneByteReader(newTestRand()) },
+	} {
+		cr := NewCountingReader(r())
+		gen := Join("-", Repeat(EFFLargeWordlist, "-", 4), HexLower(4))
+		pass, err := gen.Password(cr)
+		require.NoError(t, err)
+
+		// Counting doesn't change the generated password.
+		expect, err := gen.Password(r())
+		require.NoError(t, err)
+		assert.Equal(t, expect, pass)
+
+		// Each EFF word reads 2 bytes, with no rejections for these words.
+		assert.Equal(t, int64(4*2+4), cr.BytesRead())
+	}
+
+	cr := NewCountingReader(bytes.NewReader([]byte{0xff}))
+	_, err := Repeat(Digit, "", 2).Password(cr)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, int64(1), cr.BytesRead())
+}
+
+func TestEstimateConsumption(t *testing.T) {
+	lr, err := RepeatLength(EFFLargeWordlist, "-", 4, 20, 24)
+	require.NoError(t, err)
+	s, err := NewSentence(DefaultSentenceTemplate, nil)
+	require.NoError(t, err)
+
+	// Digit reads one byte and rejects 6 of 256 values, EFFLargeWordlist reads
+	// two bytes and rejects 3328 of 65536 values.
+	const digit, eff = 256.0 / 250, 2 * 65536.0 / 62208
+
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		expect Consumption
+	}{
+		{"Empty", Empty, Consumption{0, 0, 0}},
+		{"Digit", Digit, Consumption{1, digit, -1}},
+		{"LatinLower", LatinLower, Consumption{1, 256.0 / 234, -1}},
+		{"FromCharset", FromCharset("αβγδ"), Consumption{1, 1, 1}},
+		{"EFFLargeWordlist", EFFLargeWordlist, Consumption{2, eff, -1}},
+		{"OrchardStreetMediumV2", OrchardStreetMediumV2, Consumption{2, 2, 2}},
+		{"FromSlice", FromSlice("a", "b", "c", "d"), Consumption{1, 1, 1}},
+		{"HexLower", HexLower(8), Consumption{8, 8, 8}},
+		{"BIP39Mnemonic", BIP39Mnemonic(24), Consumption{32, 32, 32}},
+		{"SpectrePIN", SpectrePIN, Consumption{4, 4 * digit, -1}},
+		{"Repeat", Repeat(OrchardStreetMediumV2, "-", 6), Consumption{12, 12, 12}},
+		{"RepeatGen", RepeatGen(OrchardStreetMediumV2, FromSlice("-", "_"), 4), Consumption{11, 11, 11}},
+		{"Join", Join("", HexLower(4), Digit, Hyphen), Consumption{5, 4 + digit, -1}},
+		{"RandomRepeat", RandomRepeat(OrchardStreetMediumV2, "-", 2, 5), Consumption{1 + 4, 1 + 2*3.5, 1 + 10}},
+		{"Alternate", Alternate(HexLower(2), HexLower(6)), Consumption{1 + 2, 1 + 4, 1 + 6}},
+		{"Transform", LowerCase(HexUpper(3)), Consumption{3, 3, 3}},
+		{"RejectionSample", RejectionSample(HexLower(3), func(string) bool { return true }), Consumption{3, math.NaN(), -1}},
+		{"ConstrainLength", ConstrainLength(Emoji15, LengthBytes, 4, 4), Consumption{2, math.NaN(), -1}},
+		{"Sentence", s, Consumption{8, 8, 8}},
+		// lr chooses one of 80,319,998,482,044 sequences of words by reading six
+		// bytes and accepting values below three times that.
+		{"RepeatLength", lr, Consumption{6, 6 * (1 << 48) / (3 * 80319998482044.0), -1}},
+	} {
+		c, err := EstimateConsumption(tc.gen)
+		if !assert.NoErrorf(t, err, "EstimateConsumption: %s", tc.name) {
+			continue
+		}
+
+		assert.Equalf(t, tc.expect.Min, c.Min, "Min: %s", tc.name)
+		assert.Equalf(t, tc.expect.Max, c.Max, "Max: %s", tc.name)
+		assert.Equalf(t, tc.expect.Max >= 0, c.Bounded(), "Bounded: %s", tc.name)
+		switch {
+		case math.IsNaN(tc.expect.Expected):
+			assert.Truef(t, math.IsNaN(c.Expected), "Expected: %s", tc.name)
+		default:
+			assert.InDeltaf(t, tc.expect.Expected, c.Expected, 1e-9, "Expected: %s", tc.name)
+		}
+	}
+
+	wl, err := NewWordlist(strings.NewReader("a\nb\nc\n"))
+	require.NoError(t, err)
+	es, err := ParseEmojiTest(strings.NewReader("# subgroup: a\n1F600 ; fully-qualified\n"))
+	require.NoError(t, err)
+	fruit, err := FilterEmojiGroups(Emoji15, "food-fruit")
+	require.NoError(t, err)
+	plain, err := ExcludeEmojiFeatures(Emoji15, EmojiSkinTone)
+	require.NoError(t, err)
+
+	// Every kind of Generator listed by EstimateConsumption is supported.
+	for _, gen := range []Generator{
+		String("a"), FromRangeTable(unicode.Greek), Base32(4), Base32Hex(4),
+		Base64(4), Base64URL(4), Ascii85(4), fruit, plain, wl, es,
+		UpperCase(Digit), TitleCase(EFFLargeWordlist, language.English),
+		SpectreLong, Join("-", wl, es),
+	} {
+		_, err := EstimateConsumption(gen)
+		assert.NoErrorf(t, err, "EstimateConsumption(%T)", gen)
+	}
+
+	for _, gen := range []Generator{
+		GeneratorFunc(func(io.Reader) (string, error) { return "", nil }),
+		Join("", Digit, mustParseRegexp(t, "[a-z]{4}")),
+	} {
+		_, err := EstimateConsumption(gen)
+		assert.ErrorContains(t, err, "passit: cannot estimate consumption of ")
+	}
+}
+
+func TestEstimateConsumptionMeasured(t *testing.T) {
+	lr, err := RepeatLength(EFFLargeWordlist, "-", 4, 20, 24)
+	require.NoError(t, err)
+
+	// The mean number of bytes read over many passwords is close to Expected and
+	// never outside of [Min,Max].
+	for _, gen := range []Generator{
+		Repeat(Digit, "", 8),
+		Repeat(EFFLargeWordlist, "-", 4),
+		RandomRepeat(LatinMixed, "", 8, 16),
+		Alternate(SpectreLong, HexLower(4), Emoji15),
+		RepeatGen(EFFShortWordlist1, Digit, 5),
+		lr,
+	} {
+		c, err := EstimateConsumption(gen)
+		require.NoError(t, err)
+
+		const n = 5000
+		cr := NewCountingReader(newTestRand())
+		for range n {
+			start := cr.BytesRead()
+			_, err := gen.Password(cr)
+			require.NoError(t, err)
+
+			read := int(cr.BytesRead() - start)
+			assert.GreaterOrEqual(t, read, c.Min)
+			if c.Bounded() {
+				assert.LessOrEqual(t, read, c.Max)
+			}
+		}
+
+		mean := float64(cr.BytesRead()) / n
+		assert.InEpsilonf(t, c.Expected, mean, 0.02, "%#v", gen)
+	}
+}
+
+func mustParseRegexp(t *testing.T, pattern string) Generator {
+	t.Helper()
+
+	gen, err := ParseRegexp(pattern, syntax.Perl)
+	require.NoError(t, err)
+	return gen
+}
@@ -0,0 +1,267 @@
+package passit
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// wordlistGenerator is implemented by Generators that return a word chosen
+// uniformly at random from a fixed list of words.
+type wordlistGenerator interface {
+	Generator
+
+	// wordlist returns the list of words. The returned slice must not be
+	// modified.
+	wordlist() []string
+
+	// uniquelyDecodable reports whether the list is uniquely decodable. The
+	// result may be cached.
+	uniquelyDecodable() bool
+}
+
+// decodableCache lazily computes and caches whether a wordlist is uniquely
+// decodable.
+type decodableCache struct {
+	once sync.Once
+	ud   bool
+}
+
+func (dc *decodableCache) uniquelyDecodable(words []string) bool {
+	dc.once.Do(func() {
+		dc.ud = analyzeWords(words).UniquelyDecodable
+	})
+	return dc.ud
+}
+
+// CheckDecodable returns an error if sep is empty and gen is a wordlist that isn't
+// uniquely decodable, see AnalyzeWordlist. Joining words from such a list without a
+// separator can produce the same password from different sequences of words, so
+// the password has less entropy than the number of words would suggest. Repeat,
+// RepeatGen and RandomRepeat return this error from Password.
+//
+// Only the wordlists accepted by AnalyzeWordlist are checked; CheckDecodable
+// returns nil for any other Generator. A wordlist wrapped by another Generator,
+// like UpperCase or TitleCase, must be checked before it is wrapped.
+func CheckDecodable(gen Generator, sep string) error {
+	if sep != "" {
+		return nil
+	}
+
+	if wg, ok := gen.(wordlistGenerator); ok && !wg.uniquelyDecodable() {
+		return errors.New("passit: wordlist is not uniquely decodable and must be used with a separator")
+	}
+
+	return nil
+}
+
+// WordlistAnalysis describes whether the words of a wordlist can be safely
+// concatenated without a separator.
+//
+// If a list isn't uniquely decodable, two different sequences of words can produce
+// the same password when joined with an empty separator (e.g. "pass" + "word" and
+// "password"). When that happens the password has less entropy than the number of
+// words would suggest.
+type WordlistAnalysis struct {
+	// Len is the number of words in the list.
+	Len int
+
+	// PrefixFree reports whether no word in the list is a prefix of another
+	// word. Prefix-free lists are always uniquely decodable.
+	PrefixFree bool
+
+	// SuffixFree reports whether no word in the list is a suffix of another
+	// word. Suffix-free lists are always uniquely decodable.
+	SuffixFree bool
+
+	// UniquelyDecodable reports whether every concatenation of words from the
+	// list can only be produced by a single sequence of words. It is
+	// determined with the Sardinas–Patterson algorithm.
+	UniquelyDecodable bool
+
+	// Safe is a subset of the list that is uniquely decodable and so is safe to
+	// concatenate without a separator. If the list is already uniquely
+	// decodable, Safe contains every word. Otherwise it is the larger of the
+	// list with every word that is a prefix of another word removed and the
+	// list with every word that is a suffix of another word removed. The order
+	// of the words is preserved.
+	Safe []string
+}
+
+// AnalyzeWordlist analyzes the words that gen can produce to determine whether
+// they can be safely concatenated without a separator.
+//
+// gen must be one of the embedded wordlists (like OrchardStreetLongV2 or
+// EFFLargeWordlist), a Generator returned by FromSlice or a Wordlist. It returns
+// an error for any other Generator.
+func AnalyzeWordlist(gen Generator) (*WordlistAnalysis, error) {
+	wg, ok := gen.(wordlistGenerator)
+	if !ok {
+		return nil, errors.New("passit: generator is not a wordlist")
+	}
+
+	return analyzeWords(wg.wordlist()), nil
+}
+
+func analyzeWords(words []string) *WordlistAnalysis {
+	sorted := slices.Clone(words)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	reversed := make([]string, len(sorted))
+	for i, word := range sorted {
+		reversed[i] = reverseString(word)
+	}
+	slices.Sort(reversed)
+
+	wa := &WordlistAnalysis{
+		Len:        len(words),
+		PrefixFree: len(sorted) == len(words) && isPrefixFree(sorted),
+		SuffixFree: len(sorted) == len(words) && isPrefixFree(reversed),
+	}
+	wa.UniquelyDecodable = wa.PrefixFree || wa.SuffixFree ||
+		(len(sorted) == len(words) && isUniquelyDecodable(sorted))
+
+	if wa.UniquelyDecodable {
+		wa.Safe = slices.Clone(words)
+		return wa
+	}
+
+	prefixes := prefixWords(sorted, nil)
+	suffixes := prefixWords(reversed, reverseString)
+
+	safe := make([]string, 0, len(words))
+	if len(prefixes) <= len(suffixes) {
+		safe = appendWordsExcept(safe, words, prefixes)
+	} else {
+		safe = appendWordsExcept(safe, words, suffixes)
+	}
+	wa.Safe = slices.Clip(safe)
+
+	return wa
+}
+
+// isPrefixFree reports whether no word in sorted is a prefix of another. sorted
+// must be sorted and contain no duplicates.
+func isPrefixFree(sorted []string) bool {
+	// If a word is a prefix of any other word, it must be a prefix of the word
+	// that immediately follows it in sorted order.
+	for i := 1; i < len(sorted); i++ {
+		if strings.HasPrefix(sorted[i], sorted[i-1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// prefixWords returns the set of words in sorted that are a prefix of another
+// word. If mapFn is non-nil, it is applied to each word before being added to the
+// set. sorted must be sorted and contain no duplicates.
+func prefixWords(sorted []string, mapFn func(string) string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for i := 1; i < len(sorted); i++ {
+		if strings.HasPrefix(sorted[i], sorted[i-1]) {
+			word := sorted[i-1]
+			if mapFn != nil {
+				word = mapFn(word)
+			}
+			set[word] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// appendWordsExcept appends each word in words to dst, skipping those in except
+// and any duplicates. Each word appended is added to except.
+func appendWordsExcept(dst, words []string, except map[string]struct{}) []string {
+	for _, word := range words {
+		if _, ok := except[word]; !ok {
+			dst = append(dst, word)
+			except[word] = struct{}{}
+		}
+	}
+
+	return dst
+}
+
+// isUniquelyDecodable implements the Sardinas–Patterson algorithm to determine
+// whether sorted is a uniquely decodable code. sorted must be sorted and contain
+// no duplicates.
+//
+// The words are compared as byte strings. As UTF-8 is itself uniquely decodable,
+// this gives the same result as comparing the words rune by rune.
+func isUniquelyDecodable(sorted []string) bool {
+	if len(sorted) > 0 && sorted[0] == "" {
+		// The empty word can be inserted anywhere.
+		return false
+	}
+
+	set := make(map[string]struct{}, len(sorted))
+	for _, word := range sorted {
+		set[word] = struct{}{}
+	}
+
+	// The dangling suffixes are the parts left over when one sequence of words
+	// is a prefix of another. If a dangling suffix is ever itself a word, then
+	// two different sequences of words produce the same string.
+	seen := make(map[string]struct{})
+	var queue []string
+	addSuffix := func(s string) {
+		if _, ok := seen[s]; !ok && s != "" {
+			seen[s] = struct{}{}
+			queue = append(queue, s)
+		}
+	}
+
+	// Each word that is a proper prefix of another word produces a dangling
+	// suffix.
+	for _, word := range sorted {
+		forEachWithPrefix(sorted, word, func(other string) {
+			addSuffix(other[len(word):])
+		})
+	}
+
+	for len(queue) > 0 {
+		s := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		if _, ok := set[s]; ok {
+			return false
+		}
+
+		// A word that has s as a proper prefix leaves the rest of that word
+		// dangling.
+		forEachWithPrefix(sorted, s, func(word string) {
+			addSuffix(word[len(s):])
+		})
+
+		// A word that is a proper prefix of s leaves the rest of s dangling.
+		for i := 1; i < len(s); i++ {
+			if _, ok := set[s[:i]]; ok {
+				addSuffix(s[i:])
+			}
+		}
+	}
+
+	return true
+}
+
+// forEachWithPrefix calls fn for each word in sorted that has prefix as a proper
+// prefix. sorted must be sorted.
+func forEachWithPrefix(sorted []string, prefix string, fn func(string)) {
+	i, _ := slices.BinarySearch(sorted, prefix)
+	for ; i < len(sorted) && strings.HasPrefix(sorted[i], prefix); i++ {
+		if len(sorted[i]) > len(prefix) {
+			fn(sorted[i])
+		}
+	}
+}
+
+func reverseString(s string) string {
+	b := []byte(s)
+	slices.Reverse(b)
+	return string(b)
+}
@@ -0,0 +1,124 @@
+package passit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeWordlist(t *testing.T) {
+	_, err := AnalyzeWordlist(LatinLower)
+	assert.EqualError(t, err, "passit: generator is not a wordlist")
+
+	custom, err := NewWordlist(strings.NewReader("pass\nword\npassword\nsword\n"))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name                   string
+		gen                    Generator
+		len                    int
+		prefixFree, suffixFree bool
+		ud                     bool
+		safe                   int
+	}{
+		{"OrchardStreetMedium", OrchardStreetMedium, 8193, false, false, false, 8021},
+		{"OrchardStreetLong", OrchardStreetLong, 17577, false, false, false, 17256},
+		{"OrchardStreetAlpha", OrchardStreetAlpha, 1297, false, false, false, 1288},
+		{"OrchardStreetQWERTY", OrchardStreetQWERTY, 1297, false, false, false, 1294},
+		{"OrchardStreetMediumV2", OrchardStreetMediumV2, 8192, false, false, true, 8192},
+		{"OrchardStreetLongV2", OrchardStreetLongV2, 17576, false, false, true, 17576},
+		{"OrchardStreetAlphaV2", OrchardStreetAlphaV2, 1296, false, false, true, 1296},
+		{"OrchardStreetQWERTYV2", OrchardStreetQWERTYV2, 1296, false, false, true, 1296},
+		{"STS10Wordlist", STS10Wordlist, 18208, false, false, false, 15886},
+		{"EFFLargeWordlist", EFFLargeWordlist, 7776, true, false, true, 7776},
+		{"EFFShortWordlist1", EFFShortWordlist1, 1296, true, false, true, 1296},
+		{"EFFShortWordlist2", EFFShortWordlist2, 1296, true, false, true, 1296},
+		{"BIP39English", BIP39English, 2048, false, false, false, 1999},
+		{"BIP39French", BIP39French, 2048, true, false, true, 2048},
+		{"BIP39FrenchASCII", BIP39FrenchASCII, 2048, true, false, true, 2048},
+		{"BIP39Spanish", BIP39Spanish, 2048, false, false, false, 2021},
+		{"BIP39SpanishASCII", BIP39SpanishASCII, 2048, false, false, false, 2018},
+		{"BIP39Italian", BIP39Italian, 2048, true, false, true, 2048},
+		{"Emoji13", Emoji13, 3295, false, false, true, 3295},
+		{"Emoji15", Emoji15, 3655, false, false, true, 3655},
+		{"custom", custom, 4, false, false, false, 3},
+		{"prefix-free", FromSlice("0", "10", "110", "111"), 4, true, false, true, 4},
+		{"suffix-free", FromSlice("0", "01", "011", "0111"), 4, false, true, true, 4},
+		{"not UD", FromSlice("0", "01", "10"), 3, false, false, false, 2},
+		{"not UD concat", FromSlice("a", "ab", "b"), 3, false, false, false, 2},
+		{"duplicates", FromSlice("a", "b", "a"), 3, false, false, false, 2},
+		{"empty word", FromSlice("a", "", "b"), 3, false, false, false, 2},
+	} {
+		wa, err := AnalyzeWordlist(tc.gen)
+		if !assert.NoErrorf(t, err, "AnalyzeWordlist: %s", tc.name) {
+			continue
+		}
+
+		assert.Equalf(t, tc.len, wa.Len, "Len: %s", tc.name)
+		assert.Equalf(t, tc.prefixFree, wa.PrefixFree, "PrefixFree: %s", tc.name)
+		assert.Equalf(t, tc.suffixFree, wa.SuffixFree, "SuffixFree: %s", tc.name)
+		assert.Equalf(t, tc.ud, wa.UniquelyDecodable, "UniquelyDecodable: %s", tc.name)
+		assert.Lenf(t, wa.Safe, tc.safe, "Safe: %s", tc.name)
+
+		safe, err := AnalyzeWordlist(FromSlice(wa.Safe...))
+		if assert.NoErrorf(t, err, "AnalyzeWordlist(Safe): %s", tc.name) {
+			assert.Truef(t, safe.UniquelyDecodable, "Safe must be uniquely decodable: %s", tc.name)
+		}
+	}
+
+	wa, err := AnalyzeWordlist(custom)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"word", "password", "sword"}, wa.Safe)
+}
+
+func TestCheckDecodable(t *testing.T) {
+	const msg = "passit: wordlist is not uniquely decodable and must be used with a separator"
+
+	notUD := FromSlice("a", "ab", "b")
+	for _, gen := range []Generator{notUD, STS10Wordlist, OrchardStreetLong} {
+		assert.EqualError(t, CheckDecodable(gen, ""), msg)
+		assert.NoError(t, CheckDecodable(gen, " "))
+
+		// Wrapping the list hides it from CheckDecodable.
+		assert.NoError(t, CheckDecodable(UpperCase(gen), ""))
+
+		// Repeat, RepeatGen and RandomRepeat refuse to join the words.
+		for _, rep := range []Generator{
+			Repeat(gen, "", 2),
+			RepeatGen(gen, Empty, 2),
+			RepeatGen(gen, String(""), 2),
+			RandomRepeat(gen, "", 1, 2),
+			RandomRepeat(gen, "", 0, 2),
+		} {
+			_, err := rep.Password(newTestRand())
+			assert.EqualError(t, err, msg)
+		}
+
+		// A single word, or any separator, is fine.
+		for _, rep := range []Generator{
+			Repeat(gen, "", 1),
+			Repeat(gen, " ", 2),
+			RepeatGen(gen, Hyphen, 2),
+			RandomRepeat(gen, "", 0, 1),
+			RandomRepeat(gen, " ", 1, 2),
+		} {
+			_, err := rep.Password(newTestRand())
+			assert.NoError(t, err)
+		}
+	}
+
+	for _, gen := range []Generator{OrchardStreetLongV2, EFFLargeWordlist, Emoji15, FromSlice("a", "b"), LatinLower} {
+		assert.NoError(t, CheckDecodable(gen, ""))
+	}
+}
+
+func TestSliceGeneratorDecodableCached(t *testing.T) {
+	sg := FromSlice("a", "ab", "b").(*sliceGenerator)
+	assert.False(t, sg.uniquelyDecodable())
+
+	// The cached result is returned without analyzing the list again.
+	sg.list = []string{"a", "b"}
+	assert.False(t, sg.uniquelyDecodable())
+}
@@ -2,66 +2,224 @@ package passit
 
 import (
 	"io"
+	"math"
 	"strings"
 	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 
 	"go.tmthrgd.dev/passit/internal/emojilist"
 	"go.tmthrgd.dev/passit/internal/wordlist"
 )
 
 type embeddedGenerator struct {
-	once sync.Once
-
 	// Using a pointer to the embedded string allows for dead-code elimination
 	// to completely eliminate the embedded string if the Generator variable is
 	// never referenced.
 	raw *string
 
-	list []string
+	// trailingEmpty keeps the empty word that follows a trailing newline in
+	// raw. The first releases split raw on every newline, so the Orchard Street
+	// lists included an empty word, and it's kept so their output doesn't
+	// change.
+	trailingEmpty bool
+
+	// transform, if non-nil, is applied to each word of raw.
+	transform func(string) string
+
+	// offsets is a compact index of the words in raw. Word i is the substring
+	// raw[offsets[i]:offsets[i+1]-1], which excludes the newline. It's built on
+	// first use and costs four bytes per word, rather than the sixteen bytes of
+	// a string header.
+	offsetsOnce sync.Once
+	offsets     []uint32
+
+	// list holds every word as a string. It's only built when the whole list
+	// is needed, like by AnalyzeWordlist, or when transform is non-nil, and not
+	// otherwise by Password.
+	listOnce sync.Once
+	list     []string
+
+	// emoji, if non-nil, marks an emoji list and holds its group data.
+	emoji *embeddedEmoji
+
+	decodable decodableCache
 }
 
-func (eg *embeddedGenerator) Password(r io.Reader) (string, error) {
-	eg.once.Do(func() {
-		eg.list = strings.Split(*eg.raw, "\n")
+func (eg *embeddedGenerator) index() []uint32 {
+	eg.offsetsOnce.Do(func() {
+		// Some of the embedded lists end with a trailing newline which
+		// would otherwise result in an empty word.
+		raw := *eg.raw
+		if !eg.trailingEmpty {
+			raw = strings.TrimSuffix(raw, "\n")
+		}
+		if uint64(len(raw)) >= math.MaxUint32 {
+			panic("passit: embedded wordlist is too large")
+		}
+
+		offsets := make([]uint32, 1, strings.Count(raw, "\n")+2)
+		for i := 0; ; {
+			j := strings.IndexByte(raw[i:], '\n')
+			if j < 0 {
+				break
+			}
+			i += j + 1
+			offsets = append(offsets, uint32(i))
+		}
+
+		// Treat the final word as if it too was followed by a newline.
+		eg.offsets = append(offsets, uint32(len(raw)+1))
+	})
+	return eg.offsets
+}
+
+func (eg *embeddedGenerator) len() int {
+	return len(eg.index()) - 1
+}
+
+// rawWord returns word i of raw, before transform is applied.
+func (eg *embeddedGenerator) rawWord(i int) string {
+	offsets := eg.index()
+	return (*eg.raw)[offsets[i] : offsets[i+1]-1]
+}
+
+func (eg *embeddedGenerator) word(i int) string {
+	if eg.transform != nil {
+		// Transforming a word is far slower than slicing it out of raw, so
+		// the transformed words are built once and kept.
+		return eg.wordlist()[i]
+	}
+	return eg.rawWord(i)
+}
+
+func (eg *embeddedGenerator) wordlist() []string {
+	eg.listOnce.Do(func() {
+		list := make([]string, eg.len())
+		for i := range list {
+			list[i] = eg.rawWord(i)
+			if eg.transform != nil {
+				list[i] = eg.transform(list[i])
+			}
+		}
+		eg.list = list
 	})
-	return readSliceN(r, eg.list)
+	return eg.list
+}
+
+func (eg *embeddedGenerator) uniquelyDecodable() bool {
+	return eg.decodable.uniquelyDecodable(eg.wordlist())
+}
+
+func (eg *embeddedGenerator) Password(r io.Reader) (string, error) {
+	i, err := readIntN(r, eg.len())
+	if err != nil {
+		return "", err
+	}
+
+	return eg.word(i), nil
 }
 
 // OrchardStreetMedium is a Generator that returns a random word from
+// Sam Schlinkert's Orchard Street Medium List, or an empty string.
+//
+// It contains 8,193 words, the 8,192 words of the list and an empty word, and has
+// 13.000 bits of entropy per word. The empty word was included by mistake and is
+// kept so that the output doesn't change. Because of it, this list is not uniquely
+// decodable and should only be used with separators.
+//
+// This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
+//
+// Deprecated: This list includes an empty word. Use OrchardStreetMediumV2 instead.
+var OrchardStreetMedium Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetMedium, trailingEmpty: true}
+
+// OrchardStreetMediumV2 is a Generator that returns a random word from
 // Sam Schlinkert's Orchard Street Medium List.
 //
 // It contains 8,192 words and has 13.000 bits of entropy per word. This list is
 // uniquely decodable and can be used with or without separators.
 //
+// Unlike OrchardStreetMedium, it doesn't include an empty word, so it generates
+// different passwords from the same input.
+//
 // This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
-var OrchardStreetMedium Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetMedium}
+var OrchardStreetMediumV2 Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetMedium}
 
 // OrchardStreetLong is a Generator that returns a random word from
+// Sam Schlinkert's Orchard Street Long List, or an empty string.
+//
+// It contains 17,577 words, the 17,576 words of the list and an empty word, and has
+// 14.101 bits of entropy per word. The empty word was included by mistake and is
+// kept so that the output doesn't change. Because of it, this list is not uniquely
+// decodable and should only be used with separators.
+//
+// This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
+//
+// Deprecated: This list includes an empty word. Use OrchardStreetLongV2 instead.
+var OrchardStreetLong Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetLong, trailingEmpty: true}
+
+// OrchardStreetLongV2 is a Generator that returns a random word from
 // Sam Schlinkert's Orchard Street Long List.
 //
 // It contains 17,576 words and has 14.101 bits of entropy per word. This list is
 // uniquely decodable and can be used with or without separators.
 //
+// Unlike OrchardStreetLong, it doesn't include an empty word, so it generates
+// different passwords from the same input.
+//
 // This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
-var OrchardStreetLong Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetLong}
+var OrchardStreetLongV2 Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetLong}
 
 // OrchardStreetAlpha is a Generator that returns a random word from
+// Sam Schlinkert's Orchard Street Alpha List, or an empty string.
+//
+// It contains 1,297 words, the 1,296 words of the list and an empty word, and has
+// 10.341 bits of entropy per word. The empty word was included by mistake and is
+// kept so that the output doesn't change. Because of it, this list is not uniquely
+// decodable and should only be used with separators.
+//
+// This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
+//
+// Deprecated: This list includes an empty word. Use OrchardStreetAlphaV2 instead.
+var OrchardStreetAlpha Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetAlpha, trailingEmpty: true}
+
+// OrchardStreetAlphaV2 is a Generator that returns a random word from
 // Sam Schlinkert's Orchard Street Alpha List.
 //
 // It contains 1,296 words and has 10.340 bits of entropy per word. This list is
 // uniquely decodable and can be used with or without separators.
 //
+// Unlike OrchardStreetAlpha, it doesn't include an empty word, so it generates
+// different passwords from the same input.
+//
 // This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
-var OrchardStreetAlpha Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetAlpha}
+var OrchardStreetAlphaV2 Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetAlpha}
 
 // OrchardStreetQWERTY is a Generator that returns a random word from
+// Sam Schlinkert's Orchard Street QWERTY List, or an empty string.
+//
+// It contains 1,297 words, the 1,296 words of the list and an empty word, and has
+// 10.341 bits of entropy per word. The empty word was included by mistake and is
+// kept so that the output doesn't change. Because of it, this list is not uniquely
+// decodable and should only be used with separators.
+//
+// This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
+//
+// Deprecated: This list includes an empty word. Use OrchardStreetQWERTYV2 instead.
+var OrchardStreetQWERTY Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetQWERTY, trailingEmpty: true}
+
+// OrchardStreetQWERTYV2 is a Generator that returns a random word from
 // Sam Schlinkert's Orchard Street QWERTY List.
 //
 // It contains 1,296 words and has 10.340 bits of entropy per word. This list is
 // uniquely decodable and can be used with or without separators.
 //
+// Unlike OrchardStreetQWERTY, it doesn't include an empty word, so it generates
+// different passwords from the same input.
+//
 // This wordlist is licensed by Sam Schlinkert under a CC BY-SA 4.0 license.
-var OrchardStreetQWERTY Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetQWERTY}
+var OrchardStreetQWERTYV2 Generator = &embeddedGenerator{raw: &wordlist.OrchardStreetQWERTY}
 
 // STS10Wordlist is a Generator that returns a random word from Sam Schlinkert's
 // '1Password Replacement List'.
@@ -72,7 +230,7 @@ var OrchardStreetQWERTY Generator = &embeddedGenerator{raw: &wordlist.OrchardStr
 // This wordlist is licensed by Sam Schlinkert under a CC BY 3.0 license.
 //
 // Deprecated: This list is not safe to use without separators. Use one of the other
-// wordlists instead, like OrchardStreetLong.
+// wordlists instead, like OrchardStreetLongV2.
 var STS10Wordlist Generator = &embeddedGenerator{raw: &wordlist.STS10Wordlist}
 
 // EFFLargeWordlist is a Generator that returns a random word from the
@@ -102,13 +260,108 @@ var EFFShortWordlist1 Generator = &embeddedGenerator{raw: &wordlist.EFFShortWord
 // CC BY 3.0 US license.
 var EFFShortWordlist2 Generator = &embeddedGenerator{raw: &wordlist.EFFShortWordlist2}
 
+// BIP39English is a Generator that returns a random word from the BIP-39 English
+// wordlist.
+//
+// It contains 2,048 words and has 11.000 bits of entropy per word. The first four
+// letters of each word are unique. This list is not uniquely decodable and should
+// only be used with separators.
+//
+// To generate a BIP-39 mnemonic with a checksum, use BIP39Mnemonic instead.
+var BIP39English Generator = &embeddedGenerator{raw: &wordlist.BIP39English}
+
+// BIP39French is a Generator that returns a random word from the BIP-39 French
+// wordlist. Like the other BIP-39 lists, it isn't a diceware-style list and can't
+// be used with dice.
+//
+// It contains 2,048 words and has 11.000 bits of entropy per word. The words are
+// in Unicode Normalization Form C (NFC) and some contain accented letters. This
+// list is uniquely decodable and can be used with or without separators.
+var BIP39French Generator = &embeddedGenerator{raw: &wordlist.BIP39French}
+
+// BIP39FrenchASCII is like BIP39French but with the accents removed from each word
+// so that it only contains ASCII letters. No two words of BIP39French differ only
+// by their accents, so it has the same number of words.
+var BIP39FrenchASCII Generator = &embeddedGenerator{raw: &wordlist.BIP39French, transform: removeAccents}
+
+// BIP39Spanish is a Generator that returns a random word from the BIP-39 Spanish
+// wordlist.
+//
+// It contains 2,048 words and has 11.000 bits of entropy per word. The words are
+// in Unicode Normalization Form C (NFC) and some contain accented letters. This
+// list is not uniquely decodable and should only be used with separators.
+var BIP39Spanish Generator = &embeddedGenerator{raw: &wordlist.BIP39Spanish}
+
+// BIP39SpanishASCII is like BIP39Spanish but with the accents removed from each
+// word so that it only contains ASCII letters. No two words of BIP39Spanish differ
+// only by their accents, so it has the same number of words.
+var BIP39SpanishASCII Generator = &embeddedGenerator{raw: &wordlist.BIP39Spanish, transform: removeAccents}
+
+// BIP39Italian is a Generator that returns a random word from the BIP-39 Italian
+// wordlist.
+//
+// It contains 2,048 words and has 11.000 bits of entropy per word. The words only
+// contain ASCII letters. This list is uniquely decodable and can be used with or
+// without separators.
+var BIP39Italian Generator = &embeddedGenerator{raw: &wordlist.BIP39Italian}
+
+// LexiconAdjective is a Generator that returns a random English adjective, like
+// "brave" or "misty".
+//
+// It contains 512 words and has 9.000 bits of entropy per word. It is intended for
+// use with NewSentence.
+var LexiconAdjective Generator = &embeddedGenerator{raw: &wordlist.LexiconAdjectives}
+
+// LexiconNoun is a Generator that returns a random singular English noun, like
+// "otter" or "lantern".
+//
+// It contains 1,024 words and has 10.000 bits of entropy per word. It is intended
+// for use with NewSentence.
+var LexiconNoun Generator = &embeddedGenerator{raw: &wordlist.LexiconNouns}
+
+// LexiconVerb is a Generator that returns a random English verb in the past tense,
+// like "danced" or "slept". The verbs make sense without an object.
+//
+// It contains 256 words and has 8.000 bits of entropy per word. It is intended for
+// use with NewSentence.
+var LexiconVerb Generator = &embeddedGenerator{raw: &wordlist.LexiconVerbs}
+
+// LexiconAdverb is a Generator that returns a random English adverb, like
+// "quietly" or "boldly".
+//
+// It contains 256 words and has 8.000 bits of entropy per word. It is intended for
+// use with NewSentence.
+var LexiconAdverb Generator = &embeddedGenerator{raw: &wordlist.LexiconAdverbs}
+
 // Emoji13 is a Generator that returns a random fully-qualified emoji from the
 // Unicode 13.0 emoji list.
-var Emoji13 Generator = &embeddedGenerator{raw: &emojilist.Unicode13}
+//
+// Use FilterEmojiGroups and ExcludeEmojiFeatures to select a subset of the list.
+var Emoji13 Generator = &embeddedGenerator{
+	raw:   &emojilist.Unicode13,
+	emoji: new(embeddedEmoji),
+}
 
 // Emoji15 is a Generator that returns a random fully-qualified emoji from the
 // Unicode 15.0 emoji list.
-var Emoji15 Generator = &embeddedGenerator{raw: &emojilist.Unicode15}
+//
+// Use FilterEmojiGroups and ExcludeEmojiFeatures to select a subset of the list.
+var Emoji15 Generator = &embeddedGenerator{
+	raw:   &emojilist.Unicode15,
+	emoji: new(embeddedEmoji),
+}
 
 // EmojiLatest is an alias for the latest supported emoji list.
 var EmojiLatest = Emoji15
+
+// removeAccents transliterates word to ASCII by removing any combining marks,
+// like accents and the tilde of ñ, after decomposing it.
+func removeAccents(word string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(word) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
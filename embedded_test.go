@@ -1,19 +1,23 @@
 package passit
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit/internal/wordlist"
+	"golang.org/x/text/unicode/norm"
 )
 
 func allWordsValid(t *testing.T, list []string) {
 	t.Helper()
 
-	for _, v := range list {
+	for i, v := range list {
 		assert.Truef(t, utf8.ValidString(v), "utf8.ValidString(%q)", v)
+		assert.NotEmptyf(t, v, "word %d is empty", i)
 	}
 }
 
@@ -30,10 +34,20 @@ func TestEmbeddedWordlist(t *testing.T) {
 		{"OrchardStreetLong", OrchardStreetLong, "agreed stopping brilliant elongated richness populous sprung grassland"},
 		{"OrchardStreetAlpha", OrchardStreetAlpha, "bees told hymn pride boy scout hum bus"},
 		{"OrchardStreetQWERTY", OrchardStreetQWERTY, "bids trio hurry queer buyer sect hull cadres"},
+		{"OrchardStreetMediumV2", OrchardStreetMediumV2, "easier pays extracted staff furnished shortage pistol hospitals"},
+		{"OrchardStreetLongV2", OrchardStreetLongV2, "agreement stopping brilliantly elongation richness populous spun grassy"},
+		{"OrchardStreetAlphaV2", OrchardStreetAlphaV2, "bowl undo jeep punk buys shoe ideas cabin"},
+		{"OrchardStreetQWERTYV2", OrchardStreetQWERTYV2, "bus vast jets rat cards server icy cares"},
 		{"STS10Wordlist", STS10Wordlist, "winner vertigo spurs believed dude runaways poorest tourists"},
 		{"EFFLargeWordlist", EFFLargeWordlist, "reprint wool pantry unworried mummify veneering securely munchkin"},
 		{"EFFShortWordlist1", EFFShortWordlist1, "bush vapor issue ruby carol sleep hula case"},
 		{"EFFShortWordlist2", EFFShortWordlist2, "barracuda vegetable idly podiatrist bossiness satchel hexagon boxlike"},
+		{"BIP39English", BIP39English, "coast maximum fuel grain much dismiss off shell"},
+		{"BIP39French", BIP39French, "cercle kayak évasion festival loterie décaler mémoire querelle"},
+		{"BIP39FrenchASCII", BIP39FrenchASCII, "cercle kayak evasion festival loterie decaler memoire querelle"},
+		{"BIP39Spanish", BIP39Spanish, "catre margen freír gota moción dátil negocio rehén"},
+		{"BIP39SpanishASCII", BIP39SpanishASCII, "catre margen freir gota mocion datil negocio rehen"},
+		{"BIP39Italian", BIP39Italian, "citrico neve giallo imbocco onnivoro dolce parcella scindere"},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -49,11 +63,52 @@ func TestEmbeddedWordlist(t *testing.T) {
 			assert.Truef(t, utf8.ValidString(pass),
 				"utf8.ValidString(%q)", pass)
 
-			allWordsValid(t, tc.gen.(*embeddedGenerator).list)
+			eg := tc.gen.(*embeddedGenerator)
+			list := eg.wordlist()
+			if eg.trailingEmpty {
+				// The final empty word is kept for compatibility.
+				require.Equal(t, "", list[len(list)-1])
+				list = list[:len(list)-1]
+			}
+			allWordsValid(t, list)
 		})
 	}
 }
 
+func TestEmbeddedWordlistNormalization(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		gen   Generator
+		ascii bool
+	}{
+		{"BIP39French", BIP39French, false},
+		{"BIP39FrenchASCII", BIP39FrenchASCII, true},
+		{"BIP39Spanish", BIP39Spanish, false},
+		{"BIP39SpanishASCII", BIP39SpanishASCII, true},
+		{"BIP39Italian", BIP39Italian, true},
+	} {
+		list := tc.gen.(wordlistGenerator).wordlist()
+		assert.Lenf(t, list, 2048, "%s", tc.name)
+
+		seen := make(map[string]bool, len(list))
+		ascii := true
+		for _, word := range list {
+			assert.Truef(t, norm.NFC.IsNormalString(word), "%s: %q is not NFC", tc.name, word)
+			assert.Falsef(t, seen[word], "%s: %q is duplicated", tc.name, word)
+			seen[word] = true
+
+			for _, r := range word {
+				ascii = ascii && r < utf8.RuneSelf
+			}
+		}
+		assert.Equalf(t, tc.ascii, ascii, "%s: ASCII only", tc.name)
+	}
+
+	assert.Equal(t, "nino", removeAccents("niño"))
+	assert.Equal(t, "eleve", removeAccents("élève"))
+	assert.Equal(t, "eleve", removeAccents(norm.NFD.String("élève")))
+}
+
 func TestEmoji(t *testing.T) {
 	for _, tc := range []struct {
 		name   string
@@ -72,12 +127,12 @@ func TestEmoji(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.Equal(t, tc.expect, pass)
-			assert.Equal(t, size, countEmojiInString(tc.gen.(*embeddedGenerator).list, pass),
+			assert.Equal(t, size, countEmojiInString(tc.gen.(*embeddedGenerator).wordlist(), pass),
 				"countEmojiInString(%q)", pass)
 			assert.Truef(t, utf8.ValidString(pass),
 				"utf8.ValidString(%q)", pass)
 
-			allEmojiValid(t, tc.gen.(*embeddedGenerator).list)
+			allEmojiValid(t, tc.gen.(*embeddedGenerator).wordlist())
 		})
 	}
 }
@@ -100,3 +155,109 @@ outer:
 
 	return count
 }
+
+var allEmbeddedLists = []struct {
+	name string
+	gen  Generator
+}{
+	{"OrchardStreetMedium", OrchardStreetMedium},
+	{"OrchardStreetLong", OrchardStreetLong},
+	{"OrchardStreetAlpha", OrchardStreetAlpha},
+	{"OrchardStreetQWERTY", OrchardStreetQWERTY},
+	{"OrchardStreetMediumV2", OrchardStreetMediumV2},
+	{"OrchardStreetLongV2", OrchardStreetLongV2},
+	{"OrchardStreetAlphaV2", OrchardStreetAlphaV2},
+	{"OrchardStreetQWERTYV2", OrchardStreetQWERTYV2},
+	{"STS10Wordlist", STS10Wordlist},
+	{"EFFLargeWordlist", EFFLargeWordlist},
+	{"EFFShortWordlist1", EFFShortWordlist1},
+	{"EFFShortWordlist2", EFFShortWordlist2},
+	{"BIP39English", BIP39English},
+	{"BIP39French", BIP39French},
+	{"BIP39Spanish", BIP39Spanish},
+	{"BIP39Italian", BIP39Italian},
+	{"LexiconAdjective", LexiconAdjective},
+	{"LexiconNoun", LexiconNoun},
+	{"LexiconVerb", LexiconVerb},
+	{"LexiconAdverb", LexiconAdverb},
+	{"Emoji13", Emoji13},
+	{"Emoji15", Emoji15},
+}
+
+func TestEmbeddedIndex(t *testing.T) {
+	for _, tc := range allEmbeddedLists {
+		orig := tc.gen.(*embeddedGenerator)
+		eg := &embeddedGenerator{raw: orig.raw, trailingEmpty: orig.trailingEmpty}
+
+		expect := strings.Split(*eg.raw, "\n")
+		if !eg.trailingEmpty {
+			expect = strings.Split(strings.TrimSuffix(*eg.raw, "\n"), "\n")
+		}
+		assert.Equalf(t, len(expect), eg.len(), "len: %s", tc.name)
+		assert.Nilf(t, eg.list, "index must not build list: %s", tc.name)
+		assert.Equalf(t, expect, eg.wordlist(), "wordlist: %s", tc.name)
+	}
+
+	// word builds the index itself if it hasn't been built yet.
+	eg := &embeddedGenerator{raw: &wordlist.EFFLargeWordlist}
+	assert.Equal(t, "abacus", eg.word(0))
+	assert.Nil(t, eg.list, "word must not build list")
+
+	// The transformed words are only built once.
+	var calls int
+	eg = &embeddedGenerator{raw: &wordlist.BIP39French, transform: func(word string) string {
+		calls++
+		return removeAccents(word)
+	}}
+	for i := range 10 {
+		eg.word(i)
+	}
+	assert.Equal(t, "evasion", eg.word(slices.Index(eg.wordlist(), "evasion")))
+	assert.Equal(t, 2048, calls)
+}
+
+func BenchmarkEmbeddedFirstUse(b *testing.B) {
+	for _, tc := range allEmbeddedLists {
+		if tc.name != "OrchardStreetLong" && tc.name != "STS10Wordlist" && tc.name != "Emoji15" {
+			continue
+		}
+
+		raw := tc.gen.(*embeddedGenerator).raw
+		b.Run(tc.name, func(b *testing.B) {
+			tr := newTestRand()
+			b.ReportAllocs()
+
+			// The reported B/op is the steady-state memory held by the
+			// index once the generator has been used.
+			for range b.N {
+				eg := &embeddedGenerator{raw: raw}
+				_, err := eg.Password(tr)
+				if err != nil {
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEmbeddedWordlist(b *testing.B) {
+	raw := &wordlist.STS10Wordlist
+	b.ReportAllocs()
+
+	for range b.N {
+		eg := &embeddedGenerator{raw: raw}
+		_ = eg.wordlist()
+	}
+}
+
+func BenchmarkEmbeddedPassword(b *testing.B) {
+	for _, tc := range allEmbeddedLists {
+		if tc.name != "OrchardStreetLong" && tc.name != "STS10Wordlist" && tc.name != "Emoji15" {
+			continue
+		}
+
+		b.Run(tc.name, func(b *testing.B) {
+			benchmarkGeneratorPassword(b, tc.gen)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package passit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"go.tmthrgd.dev/passit/internal/emojilist"
+)
+
+// EmojiFeature is a set of emoji features that can be excluded with
+// ExcludeEmojiFeatures.
+type EmojiFeature uint
+
+const (
+	// EmojiSkinTone matches emoji with a skin tone modifier (U+1F3FB–U+1F3FF).
+	EmojiSkinTone EmojiFeature = 1 << iota
+	// EmojiZWJ matches emoji that are zero width joiner (U+200D) sequences, like
+	// family and profession emoji.
+	EmojiZWJ
+	// EmojiFlag matches emoji in the "Flags" group, including country,
+	// subdivision and other flags.
+	EmojiFlag
+	// EmojiKeycap matches keycap sequences (U+20E3), like 1️⃣ and #️⃣.
+	EmojiKeycap
+)
+
+// emojiSubgroup is a group and subgroup from emoji-test.txt.
+type emojiSubgroup struct {
+	group, subgroup string
+}
+
+// emojiList is a list of emoji along with the subgroup of each emoji.
+type emojiList struct {
+	words []string
+
+	// subgroup[i] is the index into subgroups of the subgroup of words[i].
+	subgroup  []uint16
+	subgroups []emojiSubgroup
+}
+
+// emojiListGenerator is implemented by generators that return an emoji from an
+// emojiList. It returns nil if the generator doesn't have emoji group data.
+type emojiListGenerator interface {
+	emojiList() *emojiList
+}
+
+// embeddedEmoji holds the lazily built emojiList for an embedded emoji list.
+type embeddedEmoji struct {
+	listOnce sync.Once
+	list     *emojiList
+}
+
+// embeddedEmojiData is the group and name data generated by internal/emojilist.
+// It is shared by all of the embedded emoji lists.
+var embeddedEmojiData struct {
+	once sync.Once
+
+	subgroups []emojiSubgroup
+	subgroup  map[string]uint16 // emoji to index into subgroups
+	names     emojiNames
+}
+
+func loadEmbeddedEmojiData() {
+	embeddedEmojiData.once.Do(func() {
+		d := &embeddedEmojiData
+		d.subgroup = make(map[string]uint16)
+		for _, line := range strings.Split(strings.TrimSuffix(emojilist.Groups, "\n"), "\n") {
+			group, rest, _ := strings.Cut(line, ";")
+			subgroup, emoji, _ := strings.Cut(rest, ";")
+
+			for _, e := range strings.Fields(emoji) {
+				d.subgroup[e] = uint16(len(d.subgroups))
+			}
+			d.subgroups = append(d.subgroups, emojiSubgroup{group, subgroup})
+		}
+
+		lines := strings.Split(strings.TrimSuffix(emojilist.Names, "\n"), "\n")
+		words, names := make([]string, len(lines)), make([]string, len(lines))
+		for i, line := range lines {
+			var ok bool
+			words[i], names[i], ok = strings.Cut(line, ";")
+			if !ok {
+				panic("passit: invalid embedded emoji name data")
+			}
+		}
+		d.names = newEmojiNames(words, names)
+	})
+}
+
+func (eg *embeddedGenerator) emojiList() *emojiList {
+	if eg.emoji == nil {
+		return nil
+	}
+
+	eg.emoji.listOnce.Do(func() {
+		loadEmbeddedEmojiData()
+		d := &embeddedEmojiData
+
+		words := eg.wordlist()
+		list := &emojiList{
+			words:    words,
+			subgroup: make([]uint16, len(words)),
+		}
+
+		// Only keep the subgroups used by this list, so FilterEmojiGroups
+		// rejects groups that are empty for it.
+		remap := make(map[uint16]uint16)
+		for i, word := range words {
+			sg, ok := d.subgroup[word]
+			if !ok {
+				panic("passit: invalid embedded emoji group data")
+			}
+			if _, ok := remap[sg]; !ok {
+				remap[sg] = uint16(len(list.subgroups))
+				list.subgroups = append(list.subgroups, d.subgroups[sg])
+			}
+			list.subgroup[i] = remap[sg]
+		}
+
+		eg.emoji.list = list
+	})
+	return eg.emoji.list
+}
+
+// emojiNames maps emoji to their CLDR short name.
+type emojiNames struct {
+	byEmoji map[string]string
+
+	// maxLen is the length in bytes of the longest emoji in byEmoji.
+	maxLen int
+}
+
+func newEmojiNames(words, names []string) emojiNames {
+	en := emojiNames{byEmoji: make(map[string]string, len(words))}
+	for i, word := range words {
+		en.byEmoji[word] = names[i]
+		en.maxLen = max(en.maxLen, len(word))
+	}
+	return en
+}
+
+func (en *emojiNames) name(emoji string) (string, bool) {
+	name, ok := en.byEmoji[emoji]
+	return name, ok
+}
+
+func (en *emojiNames) toNames(s string) string {
+	var b strings.Builder
+	for s != "" {
+		var matched bool
+		for n := min(len(s), en.maxLen); n > 0; n-- {
+			if name, ok := en.byEmoji[s[:n]]; ok {
+				b.WriteByte(':')
+				b.WriteString(name)
+				b.WriteByte(':')
+				s, matched = s[n:], true
+				break
+			}
+		}
+		if !matched {
+			_, n := utf8.DecodeRuneInString(s)
+			b.WriteString(s[:n])
+			s = s[n:]
+		}
+	}
+
+	return b.String()
+}
+
+// EmojiName returns the CLDR short name of emoji, like "deaf man: dark skin tone"
+// for 🧏🏿‍♂️. It reports false if emoji isn't a fully-qualified emoji.
+//
+// The names are those of Unicode 15.1 for every emoji, including those from
+// Emoji13 and Emoji15, as the CLDR names of some emoji have changed between
+// versions. For instance, 😡 is "enraged face" rather than the "pouting face" of
+// earlier versions. Use ParseEmojiTest for the names of a particular version.
+func EmojiName(emoji string) (string, bool) {
+	loadEmbeddedEmojiData()
+	return embeddedEmojiData.names.name(emoji)
+}
+
+// EmojiToNames returns s with each fully-qualified emoji replaced by its CLDR
+// short name, as returned by EmojiName, surrounded by colons, like
+// ":deaf man: dark skin tone:". All other text, including emoji that aren't
+// fully-qualified, is unchanged.
+//
+// Where emoji are adjacent, the longest emoji is matched first. This renders
+// passwords from Emoji13, Emoji15 and the emoji filters unambiguously, so they
+// can be displayed or read aloud. It can be used with Transform.
+func EmojiToNames(s string) string {
+	loadEmbeddedEmojiData()
+	return embeddedEmojiData.names.toNames(s)
+}
+
+// emojiSubset is a Generator that returns an emoji from a filtered emojiList.
+type emojiSubset struct {
+	list      emojiList
+	decodable decodableCache
+}
+
+func (es *emojiSubset) emojiList() *emojiList {
+	return &es.list
+}
+
+func (es *emojiSubset) wordlist() []string {
+	return es.list.words
+}
+
+func (es *emojiSubset) uniquelyDecodable() bool {
+	return es.decodable.uniquelyDecodable(es.list.words)
+}
+
+func (es *emojiSubset) Password(r io.Reader) (string, error) {
+	return readSliceN(r, es.list.words)
+}
+
+// FilterEmojiGroups returns a Generator that returns a random emoji from gen that
+// belongs to any of the given groups or subgroups.
+//
+// Groups and subgroups are named as they are in the Unicode emoji-test.txt file.
+// Groups have names like "Animals & Nature" and "Food & Drink", while subgroups
+// have names like "animal-mammal" and "food-fruit". Emoji13 and Emoji15 use the
+// groups and subgroups of Unicode 15.1, like EmojiName, while an *EmojiSet uses
+// those of the file it was parsed from.
+//
+// gen must be Emoji13, Emoji15, an *EmojiSet or a Generator returned by
+// FilterEmojiGroups or ExcludeEmojiFeatures. Each emoji is returned with equal
+// probability. It returns an error if any name is not a group or subgroup of gen.
+func FilterEmojiGroups(gen Generator, names ...string) (Generator, error) {
+	list, err := emojiListOf(gen)
+	if err != nil {
+		return nil, err
+	}
+
+	want := make([]bool, len(list.subgroups))
+	for _, name := range names {
+		var found bool
+		for i, sg := range list.subgroups {
+			if sg.group == name || sg.subgroup == name {
+				want[i], found = true, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("passit: unknown emoji group %q", name)
+		}
+	}
+
+	return filterEmoji(list, func(i int) bool {
+		return want[list.subgroup[i]]
+	})
+}
+
+// ExcludeEmojiFeatures returns a Generator that returns a random emoji from gen
+// that has none of the given features.
+//
+// gen must be Emoji13, Emoji15, an *EmojiSet or a Generator returned by
+// FilterEmojiGroups or ExcludeEmojiFeatures. Each emoji is returned with equal
+// probability.
+func ExcludeEmojiFeatures(gen Generator, exclude EmojiFeature) (Generator, error) {
+	list, err := emojiListOf(gen)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterEmoji(list, func(i int) bool {
+		return emojiFeatures(list.words[i], list.subgroups[list.subgroup[i]])&exclude == 0
+	})
+}
+
+func emojiListOf(gen Generator) (*emojiList, error) {
+	if eg, ok := gen.(emojiListGenerator); ok {
+		if list := eg.emojiList(); list != nil {
+			return list, nil
+		}
+	}
+
+	return nil, errors.New("passit: generator is not an emoji list")
+}
+
+func filterEmoji(list *emojiList, keep func(i int) bool) (Generator, error) {
+	subset := &emojiSubset{list: emojiList{subgroups: list.subgroups}}
+	for i, word := range list.words {
+		if keep(i) {
+			subset.list.words = append(subset.list.words, word)
+			subset.list.subgroup = append(subset.list.subgroup, list.subgroup[i])
+		}
+	}
+	if len(subset.list.words) == 0 {
+		return nil, errors.New("passit: no emoji match the filter")
+	}
+
+	return subset, nil
+}
+
+func emojiFeatures(emoji string, sg emojiSubgroup) EmojiFeature {
+	var f EmojiFeature
+	for _, r := range emoji {
+		switch {
+		case r >= 0x1F3FB && r <= 0x1F3FF:
+			f |= EmojiSkinTone
+		case r == 0x200D:
+			f |= EmojiZWJ
+		case r == 0x20E3:
+			f |= EmojiKeycap
+		}
+	}
+	if sg.group == "Flags" {
+		f |= EmojiFlag
+	}
+	return f
+}
@@ -0,0 +1,168 @@
+package passit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterEmojiGroups(t *testing.T) {
+	for _, tc := range []struct {
+		names  []string
+		len    int
+		expect string
+	}{
+		{[]string{"Animals & Nature"}, 152, "🦕 🐹 🪽 🐚 🐩 🪻"},
+		{[]string{"animal-mammal"}, 66, "🐾 🐎 🐆 🦒 🦮 🐄"},
+		{[]string{"Flags"}, 269, "🇧🇲 🇦🇬 🇨🇼 🇫🇷 🇹🇦 🇲🇿"},
+		{[]string{"food-fruit"}, 19, "🍍 🍋 🫒 🍉 🍑 🍋"},
+	} {
+		gen, err := FilterEmojiGroups(Emoji15, tc.names...)
+		if !assert.NoErrorf(t, err, "FilterEmojiGroups(%q)", tc.names) {
+			continue
+		}
+
+		assert.Lenf(t, gen.(wordlistGenerator).wordlist(), tc.len, "FilterEmojiGroups(%q)", tc.names)
+
+		pass, err := Repeat(gen, " ", 6).Password(newTestRand())
+		if assert.NoErrorf(t, err, "FilterEmojiGroups(%q)", tc.names) {
+			assert.Equalf(t, tc.expect, pass, "FilterEmojiGroups(%q)", tc.names)
+		}
+	}
+
+	all, err := FilterEmojiGroups(Emoji13, "Smileys & Emotion", "People & Body",
+		"Animals & Nature", "Food & Drink", "Travel & Places", "Activities", "Objects",
+		"Symbols", "Flags")
+	require.NoError(t, err)
+	assert.Equal(t, Emoji13.(wordlistGenerator).wordlist(), all.(wordlistGenerator).wordlist(),
+		"every emoji must be in a group")
+
+	_, err = FilterEmojiGroups(Digit, "Flags")
+	assert.EqualError(t, err, "passit: generator is not an emoji list")
+	_, err = FilterEmojiGroups(EFFLargeWordlist, "Flags")
+	assert.EqualError(t, err, "passit: generator is not an emoji list")
+	_, err = FilterEmojiGroups(Emoji15, "Animals & Nature", "animals")
+	assert.EqualError(t, err, `passit: unknown emoji group "animals"`)
+	_, err = FilterEmojiGroups(Emoji15)
+	assert.EqualError(t, err, "passit: no emoji match the filter")
+}
+
+func TestExcludeEmojiFeatures(t *testing.T) {
+	for _, tc := range []struct {
+		gen     Generator
+		exclude EmojiFeature
+		len     int
+		expect  string
+	}{
+		{Emoji13, EmojiSkinTone, 1805, "🍰 🍼 🏜️ 🙄 🇸🇳 🇨🇬"},
+		{Emoji15, EmojiSkinTone, 1870, "🤷‍♀️ 🌟 ⛳ 🍳 🥑 🔵"},
+		{Emoji15, EmojiZWJ, 2305, "🤽🏾 🌧️ 🦹 ✍🏼 🫠 🫶🏽"},
+		{Emoji15, EmojiFlag, 3386, "🧍‍♂️ 🍢 🙌🏾 👼🏻 👱🏽‍♀️ 💇🏻‍♀️"},
+		{Emoji15, EmojiKeycap, 3643, "🇨🇽 🫱🏾‍🫲🏿 🧑🏿‍🏭 🔣 🖲️ ↔️"},
+		{Emoji13, EmojiSkinTone | EmojiZWJ | EmojiFlag | EmojiKeycap, 1315, "🔥 💔 🆎 😮 ⛏️ 😪"},
+		{Emoji15, EmojiSkinTone | EmojiZWJ | EmojiFlag | EmojiKeycap, 1372, "🚥 🤞 ⤴️ ⬛ 👤 🪑"},
+	} {
+		gen, err := ExcludeEmojiFeatures(tc.gen, tc.exclude)
+		if !assert.NoErrorf(t, err, "ExcludeEmojiFeatures(%d)", tc.exclude) {
+			continue
+		}
+
+		list := gen.(wordlistGenerator).wordlist()
+		assert.Lenf(t, list, tc.len, "ExcludeEmojiFeatures(%d)", tc.exclude)
+		for _, emoji := range list {
+			if tc.exclude&EmojiSkinTone != 0 {
+				assert.NotRegexp(t, "[\U0001F3FB-\U0001F3FF]", emoji)
+			}
+			if tc.exclude&EmojiZWJ != 0 {
+				assert.NotContains(t, emoji, "‍")
+			}
+			if tc.exclude&EmojiFlag != 0 {
+				assert.NotRegexp(t, "[\U0001F1E6-\U0001F1FF]", emoji)
+			}
+			if tc.exclude&EmojiKeycap != 0 {
+				assert.NotContains(t, emoji, "⃣")
+			}
+		}
+
+		pass, err := Repeat(gen, " ", 6).Password(newTestRand())
+		if assert.NoErrorf(t, err, "ExcludeEmojiFeatures(%d)", tc.exclude) {
+			assert.Equalf(t, tc.expect, pass, "ExcludeEmojiFeatures(%d)", tc.exclude)
+			assert.Equal(t, 5, strings.Count(pass, " "))
+		}
+	}
+
+	gen, err := FilterEmojiGroups(Emoji15, "Smileys & Emotion", "Animals & Nature")
+	require.NoError(t, err)
+	gen, err = ExcludeEmojiFeatures(gen, EmojiSkinTone|EmojiZWJ)
+	require.NoError(t, err)
+	assert.Len(t, gen.(wordlistGenerator).wordlist(), 308)
+
+	gen, err = FilterEmojiGroups(gen, "animal-bird")
+	require.NoError(t, err, "filters must compose")
+	assert.NotEmpty(t, gen.(wordlistGenerator).wordlist())
+
+	_, err = ExcludeEmojiFeatures(Digit, EmojiFlag)
+	assert.EqualError(t, err, "passit: generator is not an emoji list")
+
+	flags, err := FilterEmojiGroups(Emoji15, "Flags")
+	require.NoError(t, err)
+	_, err = ExcludeEmojiFeatures(flags, EmojiFlag)
+	assert.EqualError(t, err, "passit: no emoji match the filter")
+}
+
+func TestEmojiName(t *testing.T) {
+	for _, tc := range []struct{ emoji, name string }{
+		{"🧏🏿‍♂️", "deaf man: dark skin tone"},
+		{"😀", "grinning face"},
+		{"1️⃣", "keycap: 1"},
+		{"🇫🇷", "flag: France"},
+		{"🫠", "melting face"},
+		// Names are those of Unicode 15.1 for older emoji too.
+		{"😡", "enraged face"},
+		{"🇹🇷", "flag: Türkiye"},
+		// Emoji added in Unicode 15.1 are named too.
+		{"🐦‍🔥", "phoenix"},
+	} {
+		name, ok := EmojiName(tc.emoji)
+		assert.Truef(t, ok, "EmojiName(%q)", tc.emoji)
+		assert.Equalf(t, tc.name, name, "EmojiName(%q)", tc.emoji)
+	}
+
+	for _, emoji := range []string{"", "a", "☺", "😀😀", "🏿"} {
+		_, ok := EmojiName(emoji)
+		assert.Falsef(t, ok, "EmojiName(%q)", emoji)
+	}
+
+	for _, gen := range []Generator{Emoji13, Emoji15} {
+		seen := make(map[string]string)
+		for _, emoji := range gen.(wordlistGenerator).wordlist() {
+			name, ok := EmojiName(emoji)
+			if assert.Truef(t, ok, "EmojiName(%q)", emoji) {
+				assert.NotEmptyf(t, name, "EmojiName(%q)", emoji)
+				assert.Emptyf(t, seen[name], "EmojiName(%q) is the same as %q", emoji, seen[name])
+				seen[name] = emoji
+			}
+		}
+	}
+}
+
+func TestEmojiToNames(t *testing.T) {
+	for _, tc := range []struct{ s, expect string }{
+		{"", ""},
+		{"abc", "abc"},
+		{"🧏🏿‍♂️", ":deaf man: dark skin tone:"},
+		{"a🇫🇷b", "a:flag: France:b"},
+		{"👩‍👩‍👦😀", ":family: woman, woman, boy::grinning face:"},
+		{"☺ ☺️", "☺ :smiling face:"},
+	} {
+		assert.Equalf(t, tc.expect, EmojiToNames(tc.s), "EmojiToNames(%q)", tc.s)
+	}
+
+	pass, err := Repeat(Emoji15, "", 4).Password(newTestRand())
+	require.NoError(t, err)
+	assert.Equal(t, "➡️🦸🏼‍♂️👩🏾‍🦳📱", pass)
+	assert.Equal(t, ":right arrow::man superhero: medium-light skin tone::woman: medium-dark skin tone, white hair::mobile phone:",
+		EmojiToNames(pass))
+}
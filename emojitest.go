@@ -0,0 +1,182 @@
+package passit
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// EmojiSet is a Generator that returns a random fully-qualified emoji from an
+// emoji-test.txt file. It is created by ParseEmojiTest.
+type EmojiSet struct {
+	list    emojiList
+	names   emojiNames
+	version string
+
+	decodable decodableCache
+}
+
+// ParseEmojiTest parses an emoji-test.txt file from the Unicode emoji data files,
+// like https://www.unicode.org/Public/emoji/15.1/emoji-test.txt, and returns an
+// EmojiSet of the fully-qualified emoji it lists.
+//
+// The emoji are filtered and sorted in the same way as Emoji13 and Emoji15, so
+// parsing the emoji-test.txt file for those versions returns a Generator that
+// produces the same passwords. The group, subgroup and CLDR short name of each
+// emoji are retained, so the EmojiSet can be used with FilterEmojiGroups and
+// ExcludeEmojiFeatures.
+//
+// This allows applications to use a newer emoji version than the embedded lists or
+// to pin the emoji to those supported by their clients.
+func ParseEmojiTest(r io.Reader) (*EmojiSet, error) {
+	type entry struct {
+		emoji, name string
+		subgroup    uint16
+	}
+
+	var (
+		set      EmojiSet
+		entries  []entry
+		group    string
+		seen     = make(map[string]bool)
+		lineNum  int
+		subgroup = -1
+	)
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lineNum++
+		line := strings.TrimSpace(s.Text())
+
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			comment = strings.TrimSpace(comment)
+			if v, ok := strings.CutPrefix(comment, "Version:"); ok && set.version == "" {
+				set.version = strings.TrimSpace(v)
+			} else if g, ok := strings.CutPrefix(comment, "group:"); ok {
+				group = strings.TrimSpace(g)
+			} else if sg, ok := strings.CutPrefix(comment, "subgroup:"); ok {
+				subgroup = len(set.list.subgroups)
+				set.list.subgroups = append(set.list.subgroups, emojiSubgroup{group, strings.TrimSpace(sg)})
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		data, comment, _ := strings.Cut(line, "#")
+		codePoints, status, ok := strings.Cut(data, ";")
+		if !ok {
+			return nil, fmt.Errorf("passit: emoji-test.txt line %d: missing status", lineNum)
+		}
+		if strings.TrimSpace(status) != "fully-qualified" {
+			continue
+		}
+		if subgroup < 0 {
+			return nil, fmt.Errorf("passit: emoji-test.txt line %d: emoji is not in a subgroup", lineNum)
+		}
+
+		var b strings.Builder
+		for _, cp := range strings.Fields(codePoints) {
+			r, err := strconv.ParseUint(cp, 16, 32)
+			if err != nil || !utf8.ValidRune(rune(r)) {
+				return nil, fmt.Errorf("passit: emoji-test.txt line %d: invalid code point %q", lineNum, cp)
+			}
+			b.WriteRune(rune(r))
+		}
+
+		emoji := b.String()
+		if emoji == "" {
+			return nil, fmt.Errorf("passit: emoji-test.txt line %d: missing code points", lineNum)
+		}
+		if seen[emoji] {
+			return nil, fmt.Errorf("passit: emoji-test.txt line %d: duplicate emoji %q", lineNum, emoji)
+		}
+		seen[emoji] = true
+
+		entries = append(entries, entry{emoji, emojiTestName(comment), uint16(subgroup)})
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("passit: failed to read emoji-test.txt: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("passit: emoji-test.txt contains no fully-qualified emoji")
+	}
+
+	// Sort first by number of bytes and then by string representation, the
+	// same as internal/emojilist.
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Or(cmp.Compare(len(a.emoji), len(b.emoji)), strings.Compare(a.emoji, b.emoji))
+	})
+
+	set.list.words = make([]string, len(entries))
+	set.list.subgroup = make([]uint16, len(entries))
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		set.list.words[i], set.list.subgroup[i], names[i] = e.emoji, e.subgroup, e.name
+	}
+	set.names = newEmojiNames(set.list.words, names)
+
+	return &set, nil
+}
+
+// emojiTestName returns the CLDR short name from the comment of an
+// emoji-test.txt line, like "👶🏿 E1.0 baby: dark skin tone". Older files don't
+// include the emoji version.
+func emojiTestName(comment string) string {
+	fields := strings.SplitN(strings.TrimSpace(comment), " ", 3)
+	if len(fields) == 3 && len(fields[1]) > 1 && fields[1][0] == 'E' {
+		if _, err := strconv.ParseFloat(fields[1][1:], 64); err == nil {
+			return fields[2]
+		}
+	}
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.Join(fields[1:], " ")
+}
+
+func (es *EmojiSet) emojiList() *emojiList {
+	return &es.list
+}
+
+func (es *EmojiSet) wordlist() []string {
+	return es.list.words
+}
+
+func (es *EmojiSet) uniquelyDecodable() bool {
+	return es.decodable.uniquelyDecodable(es.list.words)
+}
+
+// Password implements Generator.
+func (es *EmojiSet) Password(r io.Reader) (string, error) {
+	return readSliceN(r, es.list.words)
+}
+
+// Len returns the number of emoji in the set.
+func (es *EmojiSet) Len() int {
+	return len(es.list.words)
+}
+
+// Version returns the version from the "# Version:" header of the
+// emoji-test.txt file, like "15.1". It is empty if the header is missing.
+func (es *EmojiSet) Version() string {
+	return es.version
+}
+
+// Name returns the CLDR short name of emoji. It reports false if emoji isn't a
+// fully-qualified emoji in the set.
+func (es *EmojiSet) Name(emoji string) (string, bool) {
+	return es.names.name(emoji)
+}
+
+// ToNames is like EmojiToNames but uses the emoji in the set.
+func (es *EmojiSet) ToNames(s string) string {
+	return es.names.toNames(s)
+}
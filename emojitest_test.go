@@ -0,0 +1,251 @@
+package passit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit/internal/emojilist"
+)
+
+// buildEmojiTest reconstructs an emoji-test.txt file from the embedded emoji
+// data, in emoji-test.txt order rather than the sorted order of the list.
+func buildEmojiTest(t *testing.T, gen Generator) string {
+	t.Helper()
+
+	list := gen.(emojiListGenerator).emojiList()
+
+	var b strings.Builder
+	b.WriteString("# emoji-test.txt\n# Version: 15.0\n\n")
+
+	var group string
+	for sg, subgroup := range list.subgroups {
+		if subgroup.group != group {
+			group = subgroup.group
+			fmt.Fprintf(&b, "\n# group: %s\n", group)
+		}
+		fmt.Fprintf(&b, "\n# subgroup: %s\n", subgroup.subgroup)
+
+		for i, word := range list.words {
+			if int(list.subgroup[i]) != sg {
+				continue
+			}
+
+			var cps []string
+			for _, r := range word {
+				cps = append(cps, fmt.Sprintf("%04X", r))
+			}
+			name, ok := EmojiName(word)
+			require.True(t, ok, word)
+			fmt.Fprintf(&b, "%-54s ; fully-qualified     # %s E0.0 %s\n", strings.Join(cps, " "), word, name)
+
+			// Add an unqualified form to check it's ignored.
+			if unqualified := strings.ReplaceAll(word, "️", ""); unqualified != word {
+				fmt.Fprintf(&b, "%-54s ; unqualified         # %s E0.0 %s\n", strings.Join(cps[:1], " "), unqualified, name)
+			}
+		}
+	}
+
+	b.WriteString("\n#EOF\n")
+	return b.String()
+}
+
+func TestParseEmojiTest(t *testing.T) {
+	es, err := ParseEmojiTest(strings.NewReader(buildEmojiTest(t, Emoji15)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "15.0", es.Version())
+	assert.Equal(t, 3655, es.Len())
+	assert.Equal(t, Emoji15.(wordlistGenerator).wordlist(), es.wordlist(),
+		"must match the embedded list")
+
+	expect, err := Repeat(Emoji15, " ", 8).Password(newTestRand())
+	require.NoError(t, err)
+	pass, err := Repeat(es, " ", 8).Password(newTestRand())
+	require.NoError(t, err)
+	assert.Equal(t, expect, pass, "must generate the same passwords as Emoji15")
+	assert.Equal(t, EmojiToNames(pass), es.ToNames(pass))
+
+	name, ok := es.Name("🧏🏿‍♂️")
+	assert.True(t, ok)
+	assert.Equal(t, "deaf man: dark skin tone", name)
+
+	for _, tc := range []struct {
+		names []string
+		len   int
+	}{
+		{[]string{"Animals & Nature"}, 152},
+		{[]string{"food-fruit"}, 19},
+	} {
+		gen, err := FilterEmojiGroups(es, tc.names...)
+		if assert.NoErrorf(t, err, "FilterEmojiGroups(%q)", tc.names) {
+			assert.Lenf(t, gen.(wordlistGenerator).wordlist(), tc.len, "FilterEmojiGroups(%q)", tc.names)
+		}
+	}
+
+	gen, err := ExcludeEmojiFeatures(es, EmojiSkinTone|EmojiZWJ|EmojiFlag|EmojiKeycap)
+	require.NoError(t, err)
+	assert.Len(t, gen.(wordlistGenerator).wordlist(), 1372)
+}
+
+func TestParseEmojiTestFormat(t *testing.T) {
+	const file = `# emoji-test.txt
+# Version: 12.0
+
+# group: Smileys & Emotion
+
+# subgroup: face-smiling
+1F600                                      ; fully-qualified     # 😀 grinning face
+263A FE0F                                  ; fully-qualified     # ☺️ smiling face
+263A                                       ; unqualified         # ☺ smiling face
+
+# group: Component
+
+# subgroup: skin-tone
+1F3FB                                      ; component           # 🏻 light skin tone
+
+# group: Symbols
+
+# subgroup: keycap
+0023 FE0F 20E3                             ; fully-qualified     # #️⃣ E0.6 keycap: #
+0023 20E3                                  ; unqualified         # #⃣ E0.6 keycap: #
+
+#EOF
+`
+
+	es, err := ParseEmojiTest(strings.NewReader(file))
+	require.NoError(t, err)
+	assert.Equal(t, "12.0", es.Version())
+	assert.Equal(t, []string{"😀", "☺️", "#️⃣"}, es.wordlist())
+
+	for _, tc := range []struct{ emoji, name string }{
+		{"😀", "grinning face"},
+		{"☺️", "smiling face"},
+		{"#️⃣", "keycap: #"},
+	} {
+		name, ok := es.Name(tc.emoji)
+		assert.Truef(t, ok, "Name(%q)", tc.emoji)
+		assert.Equalf(t, tc.name, name, "Name(%q)", tc.emoji)
+	}
+	_, ok := es.Name("☺")
+	assert.False(t, ok)
+
+	pass, err := Repeat(es, "", 4).Password(newTestRand())
+	require.NoError(t, err)
+	assert.Equal(t, "😀#️⃣😀#️⃣", pass)
+	assert.Equal(t, ":grinning face::keycap: #::grinning face::keycap: #:", es.ToNames(pass))
+
+	keycaps, err := FilterEmojiGroups(es, "keycap")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#️⃣"}, keycaps.(wordlistGenerator).wordlist())
+}
+
+func TestParseEmojiTestErrors(t *testing.T) {
+	for _, tc := range []struct{ file, err string }{
+		{"", "passit: emoji-test.txt contains no fully-qualified emoji"},
+		{"# subgroup: a\n263A ; unqualified # ☺ smiling face\n", "passit: emoji-test.txt contains no fully-qualified emoji"},
+		{"# subgroup: a\n1F600 fully-qualified\n", "passit: emoji-test.txt line 2: missing status"},
+		{"1F600 ; fully-qualified # 😀 grinning face\n", "passit: emoji-test.txt line 1: emoji is not in a subgroup"},
+		{"# subgroup: a\n1F60G ; fully-qualified\n", `passit: emoji-test.txt line 2: invalid code point "1F60G"`},
+		{"# subgroup: a\n110000 ; fully-qualified\n", `passit: emoji-test.txt line 2: invalid code point "110000"`},
+		{"# subgroup: a\n ; fully-qualified\n", "passit: emoji-test.txt line 2: missing code points"},
+		{"# subgroup: a\n1F600 ; fully-qualified\n1F600 ; fully-qualified\n", `passit: emoji-test.txt line 3: duplicate emoji "😀"`},
+	} {
+		_, err := ParseEmojiTest(strings.NewReader(tc.file))
+		assert.EqualErrorf(t, err, tc.err, "ParseEmojiTest(%q)", tc.file)
+	}
+}
+
+func TestParseEmojiTestEmbedded(t *testing.T) {
+	// The embedded name data must round trip through the parser for both
+	// embedded lists.
+	for _, tc := range []struct {
+		name string
+		gen  Generator
+		raw  *string
+	}{
+		{"Emoji13", Emoji13, &emojilist.Unicode13},
+		{"Emoji15", Emoji15, &emojilist.Unicode15},
+	} {
+		es, err := ParseEmojiTest(strings.NewReader(buildEmojiTest(t, tc.gen)))
+		if assert.NoErrorf(t, err, "%s", tc.name) {
+			assert.Equalf(t, strings.Split(*tc.raw, "\n"), es.wordlist(), "%s", tc.name)
+		}
+	}
+}
+
+// emojiTestUpTo returns the lines of an emoji-test.txt file, dropping the emoji
+// that were added after version.
+func emojiTestUpTo(t *testing.T, file string, version float64) string {
+	t.Helper()
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(file, "\n") {
+		if _, comment, ok := strings.Cut(line, "; fully-qualified"); ok {
+			_, comment, _ = strings.Cut(comment, "#")
+			fields := strings.Fields(comment)
+			require.GreaterOrEqual(t, len(fields), 2, line)
+
+			var added float64
+			_, err := fmt.Sscanf(fields[1], "E%g", &added)
+			require.NoError(t, err, line)
+			if added > version {
+				continue
+			}
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func TestParseEmojiTestFixture(t *testing.T) {
+	// testdata/emoji-test-15.1.txt is the official Unicode 15.1 emoji-test.txt
+	// with everything but the header, groups, subgroups and fully-qualified
+	// emoji removed.
+	raw, err := os.ReadFile("testdata/emoji-test-15.1.txt")
+	require.NoError(t, err)
+
+	es, err := ParseEmojiTest(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "15.1", es.Version())
+	assert.Equal(t, 3773, es.Len())
+
+	// The embedded group and name data is generated from the same file.
+	embedded := make(map[string]emojiSubgroup)
+	for _, gen := range []Generator{Emoji13, Emoji15} {
+		list := gen.(emojiListGenerator).emojiList()
+		for i, word := range list.words {
+			embedded[word] = list.subgroups[list.subgroup[i]]
+		}
+	}
+	for i, word := range es.list.words {
+		name, ok := EmojiName(word)
+		assert.Truef(t, ok, "EmojiName(%q)", word)
+		expect, _ := es.Name(word)
+		assert.Equalf(t, expect, name, "EmojiName(%q)", word)
+
+		if sg, ok := embedded[word]; ok {
+			assert.Equalf(t, es.list.subgroups[es.list.subgroup[i]], sg, "subgroup of %q", word)
+		}
+	}
+
+	// Dropping the emoji added after each embedded list's version must give
+	// exactly that list.
+	for _, tc := range []struct {
+		name    string
+		gen     Generator
+		version float64
+	}{
+		{"Emoji13", Emoji13, 13.0},
+		{"Emoji15", Emoji15, 15.0},
+	} {
+		es, err := ParseEmojiTest(strings.NewReader(emojiTestUpTo(t, string(raw), tc.version)))
+		if assert.NoErrorf(t, err, "%s", tc.name) {
+			assert.Equalf(t, tc.gen.(wordlistGenerator).wordlist(), es.wordlist(), "%s", tc.name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package entropy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	ctrDRBGKeySize  = 32
+	ctrDRBGSeedSize = ctrDRBGKeySize + aes.BlockSize
+
+	// ctrDRBGReseedInterval and ctrDRBGMaxRequest are the limits from SP 800-90A
+	// Rev. 1, Table 3, for AES-256.
+	ctrDRBGReseedInterval = 1 << 48
+	ctrDRBGMaxRequest     = (1 << 19) / 8
+
+	// ctrDRBGReadSize is the size of the requests made by Read and ReadByte.
+	ctrDRBGReadSize = 512
+)
+
+// CTRDRBG is a NIST SP 800-90A Rev. 1 CTR_DRBG using AES-256 without a derivation
+// function. It is created by NewCTRDRBG.
+//
+// Without a derivation function, the entropy source must provide full entropy,
+// like crypto/rand.Reader or a hardware random number generator. Each instantiate
+// and reseed reads 48 bytes from it.
+//
+// A CTRDRBG is not safe for concurrent use.
+type CTRDRBG struct {
+	entropy io.Reader
+
+	block         cipher.Block
+	v             [aes.BlockSize]byte
+	reseedCounter uint64
+
+	predictionResistance bool
+
+	buf [ctrDRBGReadSize]byte
+	off int
+}
+
+// NewCTRDRBG instantiates a CTR_DRBG with 48 bytes of entropy input read from
+// entropy and the optional personalization string. entropy is retained and read
+// from again when the DRBG is reseeded.
+//
+// The personalization string may be at most 48 bytes long.
+func NewCTRDRBG(entropy io.Reader, personalization []byte) (*CTRDRBG, error) {
+	seed, err := padSeed(personalization, "personalization string")
+	if err != nil {
+		return nil, err
+	}
+
+	d := &CTRDRBG{entropy: entropy, off: ctrDRBGReadSize}
+	if err := d.xorEntropy(&seed); err != nil {
+		return nil, err
+	}
+
+	// CTR_DRBG_Instantiate_algorithm, SP 800-90A Rev. 1, Section 10.2.1.3.1.
+	d.setKey(make([]byte, ctrDRBGKeySize))
+	d.update(&seed)
+	d.reseedCounter = 1
+	return d, nil
+}
+
+// SetPredictionResistance enables or disables prediction resistance. When it is
+// enabled, the DRBG is reseeded from the entropy source before every request.
+//
+// Read and ReadByte make requests of 512 bytes, so prediction resistance applies
+// to each block of 512 bytes rather than to each call.
+func (d *CTRDRBG) SetPredictionResistance(enabled bool) {
+	d.predictionResistance = enabled
+}
+
+// Reseed reseeds the DRBG with 48 bytes of entropy input read from the entropy
+// source and the optional additional input, which may be at most 48 bytes long.
+//
+// The DRBG is reseeded automatically once the reseed interval of 2^48 requests is
+// reached.
+func (d *CTRDRBG) Reseed(additionalInput []byte) error {
+	seed, err := padSeed(additionalInput, "additional input")
+	if err != nil {
+		return err
+	}
+	if err := d.xorEntropy(&seed); err != nil {
+		return err
+	}
+
+	// CTR_DRBG_Reseed_algorithm, SP 800-90A Rev. 1, Section 10.2.1.4.1.
+	d.update(&seed)
+	d.reseedCounter = 1
+	d.off = ctrDRBGReadSize
+	return nil
+}
+
+// Generate fills out with pseudorandom bytes as a single request with the optional
+// additional input, which may be at most 48 bytes long. out may be at most 65536
+// bytes long.
+func (d *CTRDRBG) Generate(out, additionalInput []byte) error {
+	if len(out) > ctrDRBGMaxRequest {
+		return errors.New("entropy: CTR_DRBG request too large")
+	}
+	if len(additionalInput) > ctrDRBGSeedSize {
+		return errors.New("entropy: CTR_DRBG additional input too long")
+	}
+
+	// SP 800-90A Rev. 1, Section 9.3.1, steps 7 and 9.
+	if d.predictionResistance || d.reseedCounter > ctrDRBGReseedInterval {
+		if err := d.Reseed(additionalInput); err != nil {
+			return err
+		}
+		additionalInput = nil
+	}
+
+	// CTR_DRBG_Generate_algorithm, SP 800-90A Rev. 1, Section 10.2.1.5.1.
+	var seed [ctrDRBGSeedSize]byte
+	if len(additionalInput) > 0 {
+		copy(seed[:], additionalInput)
+		d.update(&seed)
+	}
+
+	var block [aes.BlockSize]byte
+	for n := 0; n < len(out); n += aes.BlockSize {
+		increment(&d.v)
+		d.block.Encrypt(block[:], d.v[:])
+		copy(out[n:], block[:])
+	}
+
+	d.update(&seed)
+	d.reseedCounter++
+	return nil
+}
+
+// Read implements io.Reader.
+func (d *CTRDRBG) Read(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		if d.off == len(d.buf) {
+			if err := d.Generate(d.buf[:], nil); err != nil {
+				return n, err
+			}
+			d.off = 0
+		}
+
+		c := copy(p[n:], d.buf[d.off:])
+		d.off += c
+		n += c
+	}
+
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader.
+func (d *CTRDRBG) ReadByte() (byte, error) {
+	if d.off == len(d.buf) {
+		if err := d.Generate(d.buf[:], nil); err != nil {
+			return 0, err
+		}
+		d.off = 0
+	}
+
+	b := d.buf[d.off]
+	d.off++
+	return b, nil
+}
+
+// update is CTR_DRBG_Update from SP 800-90A Rev. 1, Section 10.2.1.2.
+func (d *CTRDRBG) update(provided *[ctrDRBGSeedSize]byte) {
+	var temp [ctrDRBGSeedSize]byte
+	for n := 0; n < len(temp); n += aes.BlockSize {
+		increment(&d.v)
+		d.block.Encrypt(temp[n:], d.v[:])
+	}
+
+	subtle.XORBytes(temp[:], temp[:], provided[:])
+	d.setKey(temp[:ctrDRBGKeySize])
+	copy(d.v[:], temp[ctrDRBGKeySize:])
+}
+
+func (d *CTRDRBG) setKey(key []byte) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic("entropy: " + err.Error())
+	}
+	d.block = block
+}
+
+func (d *CTRDRBG) xorEntropy(seed *[ctrDRBGSeedSize]byte) error {
+	var entropy [ctrDRBGSeedSize]byte
+	if _, err := io.ReadFull(d.entropy, entropy[:]); err != nil {
+		return fmt.Errorf("entropy: failed to read CTR_DRBG entropy input: %w", err)
+	}
+
+	subtle.XORBytes(seed[:], seed[:], entropy[:])
+	return nil
+}
+
+func padSeed(b []byte, what string) ([ctrDRBGSeedSize]byte, error) {
+	var seed [ctrDRBGSeedSize]byte
+	if len(b) > len(seed) {
+		return seed, fmt.Errorf("entropy: CTR_DRBG %s too long", what)
+	}
+
+	copy(seed[:], b)
+	return seed, nil
+}
+
+// increment increments v as a 128-bit big-endian integer.
+func increment(v *[aes.BlockSize]byte) {
+	for i := len(v) - 1; i >= 0; i-- {
+		v[i]++
+		if v[i] != 0 {
+			return
+		}
+	}
+}
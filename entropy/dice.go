@@ -0,0 +1,324 @@
+package entropy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// ErrNeedMoreRolls is returned by a Dice reader when the rolls have been used up.
+var ErrNeedMoreRolls = errors.New("entropy: not enough rolls")
+
+// maxSimulatedRolls bounds the number of rolls RollsNeeded will simulate before
+// giving up on a generator.
+const maxSimulatedRolls = 1 << 20
+
+// Dice is a reader that converts rolls of a fair die, or flips of a fair coin,
+// into bytes. It is created by NewDice, NewCoin or NewDiceware.
+//
+// A Dice reader reads rolls typed as text. Rolls may be separated by whitespace,
+// commas or hyphens. For dice with fewer than ten sides, each digit is a single
+// roll so separators are optional, like "35162". Coin flips are written as H or T,
+// or as 1 or 2. Larger dice, like a d20, must have their rolls separated.
+//
+// When the rolls have been used up, Read and ReadByte return ErrNeedMoreRolls.
+// More rolls can then be added with AddRolls.
+type Dice struct {
+	sides int
+	in    io.RuneScanner
+
+	// rolls are rolls that have been parsed but not yet used. They are
+	// buffered by RollsNeeded and AddRolls.
+	rolls []int
+
+	// v is uniformly distributed in [0,m). It accumulates rolls until at
+	// least one byte can be extracted.
+	v, m int
+
+	// perWord and wordBytes are set in diceware mode. word accumulates the
+	// wordRolls rolls of the current word read so far.
+	perWord, wordBytes int
+	word               uint64
+	wordRolls          int
+
+	pending []byte
+
+	// simulated counts the rolls that were simulated by RollsNeeded. It is -1
+	// when not simulating.
+	simulated int
+}
+
+// NewDice returns a Dice reader that reads rolls of a die with the given number of
+// sides from rolls. sides must be between 2 and 255. rolls may be nil if the rolls
+// will be added with AddRolls.
+//
+// The rolls are combined into a uniformly distributed value, from which bytes
+// are extracted. When a byte can't be extracted without bias, the excess is
+// carried over rather than discarded, so very little entropy is lost. A d6 needs
+// about 3.1 rolls per byte, a d20 about 1.9 and a coin 8.
+func NewDice(sides int, rolls io.Reader) *Dice {
+	if sides < 2 || sides > 255 {
+		panic("entropy: sides must be between 2 and 255")
+	}
+
+	return &Dice{sides: sides, in: runeScanner(rolls), m: 1, simulated: -1}
+}
+
+// NewCoin returns a Dice reader that reads flips of a fair coin from flips.
+func NewCoin(flips io.Reader) *Dice {
+	return NewDice(2, flips)
+}
+
+// NewDiceware returns a Dice reader that reads rolls of a six-sided die from rolls
+// and maps each group of dicePerWord rolls directly to a word index, as in classic
+// diceware. dicePerWord must be between 1 and 8.
+//
+// With five dice per word, the rolls select words from passit.EFFLargeWordlist in
+// the same way as the printed EFF dice list, so that rolling 1-1-1-2-1 selects
+// "ablaze". With four dice per word, they select words from
+// passit.EFFShortWordlist1 and passit.EFFShortWordlist2. The mapping only holds
+// for generators that select one word at a time from a list of exactly 6^n
+// words, like passit.Repeat with one of those lists.
+//
+// Unlike NewDice, the bytes of a diceware reader are not uniformly distributed:
+// each group of bytes encodes a number in [0,6^n). They are only unbiased when
+// consumed as a word index from a list of exactly 6^dicePerWord words. Reading
+// them for anything else, like a shorter or longer list, a charset or
+// passit.HexLower, produces biased passwords.
+func NewDiceware(rolls io.Reader, dicePerWord int) *Dice {
+	if dicePerWord < 1 || dicePerWord > 8 {
+		panic("entropy: dice per word must be between 1 and 8")
+	}
+
+	words := 1
+	for range dicePerWord {
+		words *= 6
+	}
+
+	d := NewDice(6, rolls)
+	d.perWord = dicePerWord
+	d.wordBytes = (bits.Len(uint(words)) + 7) / 8
+	return d
+}
+
+func runeScanner(r io.Reader) io.RuneScanner {
+	if r == nil {
+		return nil
+	}
+	if rs, ok := r.(io.RuneScanner); ok {
+		return rs
+	}
+	return bufio.NewReader(r)
+}
+
+// Read implements io.Reader.
+func (d *Dice) Read(p []byte) (int, error) {
+	for n := range p {
+		b, err := d.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		p[n] = b
+	}
+
+	return len(p), nil
+}
+
+// ReadByte implements io.ByteReader.
+func (d *Dice) ReadByte() (byte, error) {
+	if len(d.pending) > 0 {
+		b := d.pending[0]
+		d.pending = d.pending[1:]
+		return b, nil
+	}
+
+	if d.perWord > 0 {
+		return d.readWord()
+	}
+
+	for {
+		roll, err := d.nextRoll()
+		if err != nil {
+			return 0, err
+		}
+
+		d.v = d.v*d.sides + roll
+		d.m *= d.sides
+		if d.m < 256 {
+			continue
+		}
+
+		// v is uniform in [0,m). If v is below the largest multiple of 256
+		// not greater than m, its low byte is uniform and the remainder is
+		// uniform in [0,m/256). Otherwise the excess is uniform in
+		// [0,m%256) and is kept for the next byte.
+		limit := d.m &^ 0xff
+		if d.v < limit {
+			b := byte(d.v)
+			d.v >>= 8
+			d.m >>= 8
+			return b, nil
+		}
+
+		d.v -= limit
+		d.m -= limit
+	}
+}
+
+func (d *Dice) readWord() (byte, error) {
+	for ; d.wordRolls < d.perWord; d.wordRolls++ {
+		roll, err := d.nextRoll()
+		if err != nil {
+			return 0, err
+		}
+		d.word = d.word*6 + uint64(roll)
+	}
+
+	// passit reads word indices as little-endian integers of the smallest
+	// number of whole bytes.
+	for i := range d.wordBytes {
+		d.pending = append(d.pending, byte(d.word>>(8*i)))
+	}
+	d.word, d.wordRolls = 0, 0
+	return d.ReadByte()
+}
+
+// nextRoll returns the next roll in [0,sides).
+func (d *Dice) nextRoll() (int, error) {
+	if len(d.rolls) > 0 {
+		roll := d.rolls[0]
+		d.rolls = d.rolls[1:]
+		return roll, nil
+	}
+
+	if d.in != nil {
+		roll, err := d.parseRoll(d.in)
+		if err != io.EOF {
+			return roll, err
+		}
+		d.in = nil
+	}
+
+	if d.simulated >= 0 {
+		if d.simulated >= maxSimulatedRolls {
+			return 0, errors.New("entropy: generator did not finish")
+		}
+
+		d.simulated++
+		return 0, nil
+	}
+	return 0, ErrNeedMoreRolls
+}
+
+// parseRoll parses the next roll from in. It returns io.EOF when there are no
+// more rolls.
+func (d *Dice) parseRoll(in io.RuneScanner) (int, error) {
+	for {
+		r, _, err := in.ReadRune()
+		switch {
+		case err != nil:
+			return 0, err
+		case unicode.IsSpace(r) || r == ',' || r == '-':
+			continue
+		case d.sides == 2 && (r == 'H' || r == 'h'):
+			return 0, nil
+		case d.sides == 2 && (r == 'T' || r == 't'):
+			return 1, nil
+		case r < '0' || r > '9':
+			return 0, fmt.Errorf("entropy: invalid roll %q", r)
+		}
+
+		roll := int(r - '0')
+		for d.sides > 9 {
+			r, _, err := in.ReadRune()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return 0, err
+			}
+			if r < '0' || r > '9' {
+				in.UnreadRune()
+				break
+			}
+
+			roll = roll*10 + int(r-'0')
+			if roll > d.sides {
+				break
+			}
+		}
+
+		if roll < 1 || roll > d.sides {
+			return 0, fmt.Errorf("entropy: roll %d out of range for a %d-sided die", roll, d.sides)
+		}
+		return roll - 1, nil
+	}
+}
+
+// AddRolls parses rolls, written as for NewDice, and adds them to the rolls to be
+// used. If any roll is invalid, it returns an error and none are added.
+//
+// The added rolls are used after any rolls already buffered, but before any still
+// to be read from the reader passed to NewDice. It's intended for a Dice created
+// with a nil reader, or whose reader has been read to the end, like by
+// RollsNeeded. An interactive program can then ask for the number of rolls
+// reported by RollsNeeded and add them as they are typed.
+func (d *Dice) AddRolls(rolls string) error {
+	in := strings.NewReader(rolls)
+
+	var parsed []int
+	for {
+		roll, err := d.parseRoll(in)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		parsed = append(parsed, roll)
+	}
+
+	d.rolls = append(d.rolls, parsed...)
+	return nil
+}
+
+// RollsNeeded reports how many more rolls are needed to generate a password with
+// gen, which is usually a passit.Generator.
+//
+// It first reads all of the remaining rolls from the reader passed to NewDice
+// until io.EOF and buffers them. With an interactive reader, like os.Stdin, it
+// blocks until the input is closed; use a nil reader with AddRolls instead. Once
+// the reader has returned io.EOF, it isn't read again and further rolls can only
+// be added with AddRolls.
+//
+// It then simulates generating a password from the buffered rolls. The result is
+// a lower bound: if some of the rolls still to come are rejected, more rolls will
+// be needed. It returns 0 if there are already enough rolls. No rolls are used,
+// so the next password is generated from the same rolls.
+func (d *Dice) RollsNeeded(gen interface {
+	Password(io.Reader) (string, error)
+}) (int, error) {
+	if d.in != nil {
+		for {
+			roll, err := d.parseRoll(d.in)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return 0, err
+			}
+			d.rolls = append(d.rolls, roll)
+		}
+		d.in = nil
+	}
+
+	sim := *d
+	sim.rolls = d.rolls[:len(d.rolls):len(d.rolls)]
+	sim.pending = d.pending[:len(d.pending):len(d.pending)]
+	sim.simulated = 0
+	if _, err := gen.Password(&sim); err != nil {
+		return 0, err
+	}
+	return sim.simulated, nil
+}
@@ -0,0 +1,206 @@
+package entropy
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+// randomRolls returns n random rolls of a die with the given number of sides,
+// separated by spaces.
+func randomRolls(t *testing.T, sides, n int) string {
+	t.Helper()
+
+	rolls := make([]string, n)
+	for i := range rolls {
+		v, err := rand.Int(rand.Reader, big.NewInt(int64(sides)))
+		require.NoError(t, err)
+		rolls[i] = strconv.Itoa(int(v.Int64()) + 1)
+	}
+	return strings.Join(rolls, " ")
+}
+
+func TestDice(t *testing.T) {
+	assert.PanicsWithValue(t, "entropy: sides must be between 2 and 255", func() {
+		NewDice(1, nil)
+	})
+	assert.PanicsWithValue(t, "entropy: sides must be between 2 and 255", func() {
+		NewDice(256, nil)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		d      *Dice
+		expect string
+	}{
+		{"d6", NewDice(6, strings.NewReader("3516245125 6413256214\n2536141523,3-1-5")), "45eae2c6525f9e7aa2"},
+		{"d6 all ones", NewDice(6, strings.NewReader(strings.Repeat("1", 28))), "0000000000000000"},
+		{"d20", NewDice(20, strings.NewReader("17 3 20 1 9 12 8 15 4 11 6 19")), "3bd8979a4676"},
+		{"coin", NewCoin(strings.NewReader("HTTH HHTH TTTH HTHH 1221 2112")), "62e469"},
+	} {
+		b, err := io.ReadAll(tc.d)
+		assert.ErrorIsf(t, err, ErrNeedMoreRolls, "ReadAll: %s", tc.name)
+		assert.Equalf(t, tc.expect, hex.EncodeToString(b), "ReadAll: %s", tc.name)
+	}
+
+	for _, tc := range []struct {
+		sides  int
+		rolls  string
+		expect string
+	}{
+		{6, "12a", `entropy: invalid roll 'a'`},
+		{6, "127", "entropy: roll 7 out of range for a 6-sided die"},
+		{6, "120", "entropy: roll 0 out of range for a 6-sided die"},
+		{20, "1 21", "entropy: roll 21 out of range for a 20-sided die"},
+		{20, "0", "entropy: roll 0 out of range for a 20-sided die"},
+		{2, "HTX", `entropy: invalid roll 'X'`},
+		{2, "H3", "entropy: roll 3 out of range for a 2-sided die"},
+	} {
+		_, err := io.ReadAll(NewDice(tc.sides, strings.NewReader(strings.Repeat(tc.rolls, 20))))
+		assert.EqualErrorf(t, err, tc.expect, "ReadAll: d%d %q", tc.sides, tc.rolls)
+	}
+}
+
+func TestDiceUniform(t *testing.T) {
+	// Enumerate every sequence of three d20 rolls. The extracted byte must be
+	// uniformly distributed over the sequences that produce one.
+	var counts [256]int
+	for a := 1; a <= 20; a++ {
+		for b := 1; b <= 20; b++ {
+			for c := 1; c <= 20; c++ {
+				d := NewDice(20, strings.NewReader(strconv.Itoa(a)+" "+strconv.Itoa(b)+" "+strconv.Itoa(c)))
+				if v, err := d.ReadByte(); err == nil {
+					counts[v]++
+				}
+			}
+		}
+	}
+
+	for v, n := range counts {
+		assert.Equalf(t, counts[0], n, "count of %#02x", v)
+	}
+	assert.NotZero(t, counts[0])
+}
+
+func TestDiceware(t *testing.T) {
+	assert.PanicsWithValue(t, "entropy: dice per word must be between 1 and 8", func() {
+		NewDiceware(nil, 0)
+	})
+
+	for _, tc := range []struct {
+		gen     passit.Generator
+		perWord int
+		rolls   string
+		expect  string
+	}{
+		{passit.Repeat(passit.EFFLargeWordlist, " ", 3), 5, "11111 11121 66666", "abacus ablaze zoom"},
+		{passit.Repeat(passit.EFFLargeWordlist, "-", 4), 5, "35162-45125-64132-56214", "john-pounce-unholy-starlight"},
+		{passit.Repeat(passit.EFFShortWordlist1, " ", 3), 4, "1111 1112 6666", "acid acorn zoom"},
+	} {
+		pass, err := tc.gen.Password(NewDiceware(strings.NewReader(tc.rolls), tc.perWord))
+		if assert.NoErrorf(t, err, "Password: %q", tc.rolls) {
+			assert.Equalf(t, tc.expect, pass, "Password: %q", tc.rolls)
+		}
+	}
+
+	// Rolls for a partial word are kept until the rest are available.
+	d := NewDiceware(strings.NewReader("111"), 5)
+	_, err := d.ReadByte()
+	assert.ErrorIs(t, err, ErrNeedMoreRolls)
+	d.in = strings.NewReader("21")
+	pass, err := passit.EFFLargeWordlist.Password(d)
+	require.NoError(t, err)
+	assert.Equal(t, "ablaze", pass)
+}
+
+func TestDiceRollsNeeded(t *testing.T) {
+	gen := passit.Repeat(passit.EFFLargeWordlist, "-", 6)
+
+	d := NewDiceware(strings.NewReader(""), 5)
+	n, err := d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Equal(t, 30, n)
+
+	d = NewDiceware(strings.NewReader("11111 11121 6"), 5)
+	n, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Equal(t, 19, n)
+
+	// Each EFFLargeWordlist word needs two bytes, so 12 bytes in total. At
+	// log2(6) bits per roll, that is at least 38 rolls of a d6.
+	d = NewDice(6, strings.NewReader(""))
+	n, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Equal(t, 39, n)
+
+	for range 20 {
+		rolls := randomRolls(t, 6, n)
+		d := NewDice(6, strings.NewReader(rolls))
+		more, err := d.RollsNeeded(gen)
+		require.NoError(t, err)
+
+		// RollsNeeded doesn't consume any rolls and is a lower bound.
+		_, err = gen.Password(d)
+		if more == 0 {
+			assert.NoError(t, err, rolls)
+		} else {
+			assert.ErrorIs(t, err, ErrNeedMoreRolls, rolls)
+		}
+	}
+
+	d = NewDice(6, strings.NewReader(randomRolls(t, 6, 200)))
+	n, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	d = NewDice(6, strings.NewReader("12x"))
+	_, err = d.RollsNeeded(gen)
+	assert.EqualError(t, err, `entropy: invalid roll 'x'`)
+
+	d = NewDice(6, strings.NewReader(""))
+	_, err = d.RollsNeeded(passit.RejectionSample(passit.Digit, func(string) bool { return false }))
+	assert.EqualError(t, err, "passit: failed to read entropy: entropy: generator did not finish")
+}
+
+func TestDiceAddRolls(t *testing.T) {
+	gen := passit.Repeat(passit.EFFLargeWordlist, "-", 2)
+
+	d := NewDiceware(nil, 5)
+	_, err := gen.Password(d)
+	assert.ErrorIs(t, err, ErrNeedMoreRolls)
+
+	n, err := d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	require.NoError(t, d.AddRolls("11111"))
+	assert.EqualError(t, d.AddRolls("11121 7"), "entropy: roll 7 out of range for a 6-sided die")
+
+	n, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n, "invalid rolls must not be added")
+
+	require.NoError(t, d.AddRolls("1-1-1-2-1"))
+	n, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	pass, err := gen.Password(d)
+	require.NoError(t, err)
+	assert.Equal(t, "abacus-ablaze", pass)
+
+	// Added rolls are used after those already read from the reader.
+	d = NewDice(6, strings.NewReader("123"))
+	_, err = d.RollsNeeded(gen)
+	require.NoError(t, err)
+	require.NoError(t, d.AddRolls("456"))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, d.rolls)
+}
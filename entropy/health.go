@@ -0,0 +1,190 @@
+package entropy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrHealthTest is returned, wrapped, by a HealthTest reader once the entropy
+// source has failed a health test.
+var ErrHealthTest = errors.New("entropy: health test failed")
+
+const (
+	// healthTestAlphaLog2 is the base-2 logarithm of the false positive
+	// probability of the health tests, 2^-20, as recommended by SP 800-90B.
+	healthTestAlphaLog2 = -20
+
+	// aptWindow is the window size of the adaptive proportion test for
+	// non-binary samples.
+	aptWindow = 512
+
+	// startupSamples is the number of samples tested, and discarded, before
+	// the first output.
+	startupSamples = 1024
+)
+
+// HealthTest is a reader that applies the NIST SP 800-90B continuous health tests
+// to the bytes read from an entropy source. It is created by NewHealthTest.
+//
+// Each byte is treated as a sample. The repetition count test detects a source
+// that has become stuck on a single value and the adaptive proportion test
+// detects a source that produces one value far more often than it should. Both
+// tests use a false positive probability of 2^-20.
+//
+// Once a test fails, every subsequent read returns an error wrapping
+// ErrHealthTest and no further bytes are returned. Generators then return the
+// error instead of a password.
+type HealthTest struct {
+	r  io.Reader
+	br io.ByteReader
+
+	rctCutoff, aptCutoff int
+
+	// last and rctCount are the state of the repetition count test.
+	last     byte
+	rctCount int
+
+	// aptRef, aptCount and aptIndex are the state of the adaptive proportion
+	// test.
+	aptRef             byte
+	aptCount, aptIndex int
+
+	started bool
+	err     error
+}
+
+// NewHealthTest returns a HealthTest that reads from r, which claims minEntropy
+// bits of min-entropy per byte. minEntropy must be greater than 0 and at most 8.
+//
+// The cutoffs of the tests are calculated from minEntropy as described in SP
+// 800-90B, Sections 4.4.1 and 4.4.2. Before the first byte is returned, 1024
+// bytes are read from r, tested and discarded as a start-up test.
+func NewHealthTest(r io.Reader, minEntropy float64) *HealthTest {
+	if !(minEntropy > 0 && minEntropy <= 8) {
+		panic("entropy: min-entropy must be greater than 0 and at most 8")
+	}
+
+	br, _ := r.(io.ByteReader)
+	return &HealthTest{
+		r:  r,
+		br: br,
+
+		rctCutoff: repetitionCountCutoff(minEntropy),
+		aptCutoff: adaptiveProportionCutoff(minEntropy),
+	}
+}
+
+// repetitionCountCutoff returns C = 1 + ⌈-log2(α)/H⌉.
+func repetitionCountCutoff(minEntropy float64) int {
+	return 1 + int(math.Ceil(-healthTestAlphaLog2/minEntropy))
+}
+
+// adaptiveProportionCutoff returns C = 1 + CRITBINOM(W, 2^-H, 1-α), where
+// CRITBINOM(n, p, q) is the smallest k such that the binomial distribution with n
+// trials and probability p has a cumulative probability at k of at least q.
+func adaptiveProportionCutoff(minEntropy float64) int {
+	p := math.Exp2(-minEntropy)
+	q := 1 - math.Exp2(healthTestAlphaLog2)
+
+	lgn, _ := math.Lgamma(aptWindow + 1)
+	var cdf float64
+	for k := 0; k < aptWindow; k++ {
+		lgk, _ := math.Lgamma(float64(k) + 1)
+		lgnk, _ := math.Lgamma(float64(aptWindow-k) + 1)
+		cdf += math.Exp(lgn - lgk - lgnk + float64(k)*math.Log(p) + float64(aptWindow-k)*math.Log1p(-p))
+		if cdf >= q {
+			return 1 + k
+		}
+	}
+	return 1 + aptWindow
+}
+
+// Read implements io.Reader.
+func (h *HealthTest) Read(p []byte) (int, error) {
+	if err := h.start(); err != nil {
+		return 0, err
+	}
+
+	n, err := h.r.Read(p)
+	for _, b := range p[:n] {
+		if h.test(b) != nil {
+			return 0, h.err
+		}
+	}
+	return n, err
+}
+
+// ReadByte implements io.ByteReader.
+func (h *HealthTest) ReadByte() (byte, error) {
+	if err := h.start(); err != nil {
+		return 0, err
+	}
+
+	b, err := h.readByte()
+	if err != nil {
+		return 0, err
+	}
+	return b, h.test(b)
+}
+
+func (h *HealthTest) readByte() (byte, error) {
+	if h.br != nil {
+		return h.br.ReadByte()
+	}
+
+	var buf [1]byte
+	_, err := io.ReadFull(h.r, buf[:])
+	return buf[0], err
+}
+
+func (h *HealthTest) start() error {
+	if h.err != nil || h.started {
+		return h.err
+	}
+
+	for range startupSamples {
+		b, err := h.readByte()
+		if err != nil {
+			return err
+		}
+		if err := h.test(b); err != nil {
+			return err
+		}
+	}
+
+	h.started = true
+	return nil
+}
+
+// test applies the repetition count test (SP 800-90B, Section 4.4.1) and the
+// adaptive proportion test (Section 4.4.2) to the sample b.
+func (h *HealthTest) test(b byte) error {
+	if h.err != nil {
+		return h.err
+	}
+
+	if h.rctCount > 0 && b == h.last {
+		h.rctCount++
+		if h.rctCount >= h.rctCutoff {
+			h.err = fmt.Errorf("%w: repetition count test: %d consecutive identical bytes", ErrHealthTest, h.rctCount)
+			return h.err
+		}
+	} else {
+		h.last, h.rctCount = b, 1
+	}
+
+	if h.aptIndex == 0 {
+		h.aptRef, h.aptCount = b, 1
+	} else if b == h.aptRef {
+		h.aptCount++
+		if h.aptCount >= h.aptCutoff {
+			h.err = fmt.Errorf("%w: adaptive proportion test: %d of %d bytes identical", ErrHealthTest, h.aptCount, h.aptIndex+1)
+			return h.err
+		}
+	}
+	h.aptIndex = (h.aptIndex + 1) % aptWindow
+
+	return nil
+}
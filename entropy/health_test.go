@@ -0,0 +1,131 @@
+package entropy
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+func TestHealthTestCutoffs(t *testing.T) {
+	// The adaptive proportion test cutoffs are from SP 800-90B, Table 2, and the
+	// repetition count test cutoffs from Section 4.4.1.
+	for _, tc := range []struct {
+		minEntropy float64
+		rct, apt   int
+	}{
+		{0.5, 41, 410},
+		{1, 21, 311},
+		{2, 11, 177},
+		{4, 6, 62},
+		{8, 4, 13},
+	} {
+		h := NewHealthTest(rand.Reader, tc.minEntropy)
+		assert.Equalf(t, tc.rct, h.rctCutoff, "repetition count cutoff: H=%v", tc.minEntropy)
+		assert.Equalf(t, tc.apt, h.aptCutoff, "adaptive proportion cutoff: H=%v", tc.minEntropy)
+	}
+
+	for _, minEntropy := range []float64{0, -1, 8.5} {
+		assert.PanicsWithValuef(t, "entropy: min-entropy must be greater than 0 and at most 8", func() {
+			NewHealthTest(rand.Reader, minEntropy)
+		}, "NewHealthTest(%v)", minEntropy)
+	}
+}
+
+func TestHealthTestPass(t *testing.T) {
+	gen := passit.Repeat(passit.EFFLargeWordlist, "-", 8)
+	for _, r := range []io.Reader{
+		rand.Reader,
+		NewAESCTR([]byte("secret"), nil),
+		iotest.OneByteReader(NewAESCTR([]byte("secret"), nil)),
+	} {
+		h := NewHealthTest(r, 8)
+		for range 1000 {
+			_, err := gen.Password(h)
+			require.NoError(t, err)
+		}
+	}
+
+	// The start-up test bytes are discarded.
+	expect := readN(t, NewAESCTR([]byte("secret"), nil), startupSamples+100)
+	got := readN(t, NewHealthTest(NewAESCTR([]byte("secret"), nil), 8), 100)
+	assert.Equal(t, expect[startupSamples:], got)
+}
+
+// stuckReader returns random bytes until after bytes have been read and then
+// returns the same byte forever.
+type stuckReader struct {
+	r     io.Reader
+	after int
+}
+
+func (s *stuckReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	for i := range p[:n] {
+		if s.after <= 0 {
+			p[i] = 0x42
+		}
+		s.after--
+	}
+	return n, err
+}
+
+func TestHealthTestRepetitionCount(t *testing.T) {
+	h := NewHealthTest(&stuckReader{rand.Reader, startupSamples + 100}, 8)
+	readN(t, h, 50)
+
+	gen := passit.Repeat(passit.Digit, "", 100)
+	_, err := gen.Password(h)
+	assert.ErrorIs(t, err, ErrHealthTest)
+	assert.EqualError(t, err, "passit: failed to read entropy: entropy: health test failed: repetition count test: 4 consecutive identical bytes")
+
+	// The failure is permanent.
+	_, err = h.ReadByte()
+	assert.ErrorIs(t, err, ErrHealthTest)
+	n, err := h.Read(make([]byte, 10))
+	assert.Zero(t, n)
+	assert.ErrorIs(t, err, ErrHealthTest)
+
+	// A source that is stuck from the start fails the start-up test.
+	h = NewHealthTest(&stuckReader{rand.Reader, 0}, 1)
+	_, err = h.ReadByte()
+	assert.EqualError(t, err, "entropy: health test failed: repetition count test: 21 consecutive identical bytes")
+}
+
+func TestHealthTestAdaptiveProportion(t *testing.T) {
+	// Every other byte is zero, so no byte repeats consecutively but half of
+	// the bytes are identical.
+	biased := readN(t, NewAESCTR([]byte("secret"), nil), 2*startupSamples)
+	for i := range biased {
+		if i%2 == 0 {
+			biased[i] = 0
+		} else {
+			biased[i] |= 1
+		}
+	}
+
+	h := NewHealthTest(bytes.NewReader(biased), 8)
+	_, err := h.Read(make([]byte, 1))
+	assert.EqualError(t, err, "entropy: health test failed: adaptive proportion test: 13 of 25 bytes identical")
+
+	// The same source passes with a sufficiently low claimed min-entropy.
+	h = NewHealthTest(bytes.NewReader(biased), 0.5)
+	_, err = h.Read(make([]byte, 1))
+	assert.NoError(t, err)
+}
+
+func TestHealthTestReadError(t *testing.T) {
+	h := NewHealthTest(iotest.ErrReader(io.ErrClosedPipe), 8)
+	_, err := h.ReadByte()
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	assert.NotErrorIs(t, err, ErrHealthTest)
+
+	h = NewHealthTest(io.LimitReader(rand.Reader, startupSamples+10), 8)
+	_, err = io.ReadAll(h)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,59 @@
+package entropy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// defaultMixBytes is the number of bytes read from a MixSource with a zero N.
+const defaultMixBytes = 32
+
+// MixSource is an entropy source to be combined by NewMix.
+type MixSource struct {
+	// R is the entropy source, like crypto/rand.Reader, a Dice reader or a
+	// hardware token.
+	R io.Reader
+
+	// N is the number of bytes to read from R. If N is zero, 32 bytes are
+	// read.
+	N int
+}
+
+// NewMix returns a Stream that combines the output of several entropy sources, so
+// that its output is unpredictable as long as any one of the sources is.
+//
+// N bytes are read from each source when NewMix is called. The bytes from each
+// source, each prefixed by its length as a big-endian uint32, are concatenated in
+// order and used as the input keying material for HKDF-SHA256 with no salt. The
+// returned Stream is the AES-256-CTR keystream keyed from the HKDF output, as in
+// NewAESCTR, with the stream name "mix" and no labels. The sources aren't read
+// again.
+//
+// Each source should provide at least 256 bits of entropy so that any one of them
+// is sufficient on its own. A Dice reader for a d6 needs a little over 100 rolls
+// to provide the default 32 bytes.
+func NewMix(sources ...MixSource) (*Stream, error) {
+	if len(sources) == 0 {
+		panic("entropy: NewMix called without any sources")
+	}
+
+	var ikm []byte
+	for i, src := range sources {
+		n := src.N
+		switch {
+		case n < 0:
+			panic("entropy: MixSource N cannot be negative")
+		case n == 0:
+			n = defaultMixBytes
+		}
+
+		ikm = binary.BigEndian.AppendUint32(ikm, uint32(n))
+		ikm = append(ikm, make([]byte, n)...)
+		if _, err := io.ReadFull(src.R, ikm[len(ikm)-n:]); err != nil {
+			return nil, fmt.Errorf("entropy: failed to read from mix source %d: %w", i, err)
+		}
+	}
+
+	return newAESCTR(hkdfExtract(ikm, nil), info("mix", nil)), nil
+}
@@ -0,0 +1,82 @@
+package entropy
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+func TestNewMix(t *testing.T) {
+	assert.PanicsWithValue(t, "entropy: NewMix called without any sources", func() {
+		NewMix()
+	})
+	assert.PanicsWithValue(t, "entropy: MixSource N cannot be negative", func() {
+		NewMix(MixSource{rand.Reader, -1})
+	})
+
+	// The key and initial counter block are HKDF-SHA256 of the length prefixed
+	// source bytes as calculated by an independent implementation.
+	keyIV := mustDecodeHex("b542610ddf56420deaf7a9820a04d43c2b4482fff684456e18e06b11e671bb8e962e042f2ae5ebc746666f6b75384033")
+	block, err := aes.NewCipher(keyIV[:32])
+	require.NoError(t, err)
+	expect := make([]byte, 100)
+	cipher.NewCTR(block, keyIV[32:]).XORKeyStream(expect, expect)
+
+	newMix := func() *Stream {
+		s, err := NewMix(
+			MixSource{R: bytes.NewReader(bytes.Repeat([]byte{0x01}, 100))},
+			MixSource{R: strings.NewReader("hello, world"), N: 5},
+		)
+		require.NoError(t, err)
+		return s
+	}
+	assert.Equal(t, expect, readN(t, newMix(), len(expect)))
+
+	pass, err := passit.Repeat(passit.EFFLargeWordlist, "-", 4).Password(newMix())
+	require.NoError(t, err)
+	assert.Equal(t, "proving-culminate-outright-flashcard", pass)
+}
+
+func TestNewMixSources(t *testing.T) {
+	fixed := func() io.Reader { return bytes.NewReader(make([]byte, 64)) }
+
+	// Changing any one source changes the output.
+	a := readN(t, must(NewMix(MixSource{R: fixed()}, MixSource{R: fixed()})), 32)
+	b := readN(t, must(NewMix(MixSource{R: fixed()}, MixSource{R: rand.Reader})), 32)
+	c := readN(t, must(NewMix(MixSource{R: rand.Reader}, MixSource{R: fixed()})), 32)
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.NotEqual(t, b, c)
+
+	// Moving bytes between sources changes the output.
+	d := readN(t, must(NewMix(MixSource{R: fixed(), N: 31}, MixSource{R: fixed(), N: 33})), 32)
+	assert.NotEqual(t, a, d)
+
+	dice := NewDice(6, strings.NewReader(randomRolls(t, 6, 120)))
+	_, err := NewMix(MixSource{R: rand.Reader}, MixSource{R: dice})
+	assert.NoError(t, err)
+
+	dice = NewDice(6, strings.NewReader(randomRolls(t, 6, 50)))
+	_, err = NewMix(MixSource{R: rand.Reader}, MixSource{R: dice})
+	assert.ErrorIs(t, err, ErrNeedMoreRolls)
+	assert.ErrorContains(t, err, "entropy: failed to read from mix source 1: ")
+
+	_, err = NewMix(MixSource{R: iotest.ErrReader(io.ErrClosedPipe)})
+	assert.EqualError(t, err, "entropy: failed to read from mix source 0: io: read/write on closed pipe")
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
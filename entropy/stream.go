@@ -0,0 +1,128 @@
+package entropy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+)
+
+// Stream is a deterministic stream of bytes. It is created by NewHKDF, NewAESCTR
+// or NewMix.
+type Stream struct {
+	buf []byte
+	off int
+
+	// fill refills buf. It returns io.EOF when the stream is exhausted.
+	fill func(buf []byte) ([]byte, error)
+	err  error
+}
+
+// Read implements io.Reader.
+func (s *Stream) Read(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		if s.off == len(s.buf) && !s.refill() {
+			return n, s.err
+		}
+
+		c := copy(p[n:], s.buf[s.off:])
+		s.off += c
+		n += c
+	}
+
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader.
+func (s *Stream) ReadByte() (byte, error) {
+	if s.off == len(s.buf) && !s.refill() {
+		return 0, s.err
+	}
+
+	b := s.buf[s.off]
+	s.off++
+	return b, nil
+}
+
+func (s *Stream) refill() bool {
+	if s.err != nil {
+		return false
+	}
+
+	s.buf, s.err = s.fill(s.buf)
+	s.off = 0
+	return s.err == nil
+}
+
+// NewHKDF returns a Stream of the HKDF-SHA256 output for secret, salt and labels.
+// salt may be nil.
+//
+// The Stream returns io.EOF after 255*32 bytes, the most that HKDF-SHA256 can
+// produce.
+func NewHKDF(secret, salt []byte, labels ...Label) *Stream {
+	return &Stream{fill: newHKDFExpand(hkdfExtract(secret, salt), info("hkdf-sha256", labels))}
+}
+
+// NewAESCTR returns a Stream of the AES-256-CTR keystream with a key and initial
+// counter block derived from secret, salt and labels with HKDF-SHA256. salt may be
+// nil.
+func NewAESCTR(secret, salt []byte, labels ...Label) *Stream {
+	return newAESCTR(hkdfExtract(secret, salt), info("aes-256-ctr", labels))
+}
+
+// newAESCTR returns a Stream of the AES-256-CTR keystream with a key and initial
+// counter block from HKDF-Expand(prk, info, 48).
+func newAESCTR(prk, info []byte) *Stream {
+	var keyIV [32 + aes.BlockSize]byte
+	expand := newHKDFExpand(prk, info)
+	for off, buf := 0, []byte(nil); off < len(keyIV); off += len(buf) {
+		buf, _ = expand(buf)
+		copy(keyIV[off:], buf)
+	}
+
+	block, err := aes.NewCipher(keyIV[:32])
+	if err != nil {
+		panic("entropy: " + err.Error())
+	}
+	ctr := cipher.NewCTR(block, keyIV[32:])
+
+	return &Stream{fill: func(buf []byte) ([]byte, error) {
+		if buf == nil {
+			buf = make([]byte, 32*aes.BlockSize)
+		}
+		clear(buf)
+		ctr.XORKeyStream(buf, buf)
+		return buf, nil
+	}}
+}
+
+func hkdfExtract(secret, salt []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(secret)
+	return mac.Sum(nil)
+}
+
+// newHKDFExpand returns a function that returns successive HKDF-Expand output
+// blocks of sha256.Size bytes for prk and info. The block is written into buf,
+// which must be nil or a slice previously returned.
+func newHKDFExpand(prk, info []byte) func(buf []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, prk)
+	var counter byte
+
+	return func(buf []byte) ([]byte, error) {
+		if counter == 255 {
+			return buf[:0], io.EOF
+		}
+
+		mac.Reset()
+		if counter > 0 {
+			mac.Write(buf)
+		}
+		mac.Write(info)
+		counter++
+		mac.Write([]byte{counter})
+		return mac.Sum(buf[:0]), nil
+	}
+}
@@ -0,0 +1,157 @@
+package entropy
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func readN(t *testing.T, r io.Reader, n int) []byte {
+	t.Helper()
+
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	require.NoError(t, err)
+	return b
+}
+
+func TestHKDFRFC5869(t *testing.T) {
+	// Test cases 1 and 3 from RFC 5869, appendix A.
+	for _, tc := range []struct {
+		ikm, salt, info, prk, okm string
+	}{
+		{
+			"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			"000102030405060708090a0b0c",
+			"f0f1f2f3f4f5f6f7f8f9",
+			"077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+			"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{
+			"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			"",
+			"",
+			"19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+			"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+	} {
+		prk := hkdfExtract(mustDecodeHex(tc.ikm), mustDecodeHex(tc.salt))
+		assert.Equal(t, tc.prk, hex.EncodeToString(prk))
+
+		s := &Stream{fill: newHKDFExpand(prk, mustDecodeHex(tc.info))}
+		okm := mustDecodeHex(tc.okm)
+		assert.Equal(t, tc.okm, hex.EncodeToString(readN(t, s, len(okm))))
+	}
+}
+
+func TestInfo(t *testing.T) {
+	assert.Equal(t, []byte("passit/entropy v1\x00hkdf-sha256"), info("hkdf-sha256", nil))
+	assert.Equal(t, []byte("passit/entropy v1\x00aes-256-ctr"+
+		"\x00\x00\x00\x04site\x00\x00\x00\x0bexample.com"+
+		"\x00\x00\x00\x07counter\x00\x00\x00\x0242"+
+		"\x00\x00\x00\x00\x00\x00\x00\x00"),
+		info("aes-256-ctr", []Label{Site("example.com"), Counter(42), {}}))
+
+	// Labels are unambiguous even when the names and values run together.
+	assert.NotEqual(t,
+		info("hkdf-sha256", []Label{{"ab", "c"}}),
+		info("hkdf-sha256", []Label{{"a", "bc"}}))
+}
+
+var testLabels = []Label{Site("example.com"), User("alice"), Counter(1)}
+
+func TestNewHKDF(t *testing.T) {
+	for _, tc := range []struct {
+		secret, salt string
+		labels       []Label
+		expect       string
+	}{
+		{"secret", "", nil, "baa1d172781712984eaf545b04046d631a1006e34469968dc514a9dc71ed5da5"},
+		{"secret", "salt", testLabels, "55d84b4f77e692abfcebd3b30a453d8004bb16c3108072f8c1b3053edc9da5bb12e4a6e019c6f58e62f2fbbb53e67ee5"},
+	} {
+		s := NewHKDF([]byte(tc.secret), []byte(tc.salt), tc.labels...)
+		assert.Equal(t, tc.expect, hex.EncodeToString(readN(t, s, len(tc.expect)/2)))
+	}
+
+	s := NewHKDF([]byte("secret"), nil)
+	readN(t, s, 255*32-1)
+	_, err := s.ReadByte()
+	assert.NoError(t, err)
+	_, err = s.ReadByte()
+	assert.ErrorIs(t, err, io.EOF)
+	n, err := s.Read(make([]byte, 1))
+	assert.Zero(t, n)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestNewAESCTR(t *testing.T) {
+	// The key and initial counter block are HKDF-SHA256("secret", "salt", info)
+	// as calculated by an independent implementation.
+	keyIV := mustDecodeHex("2732b64635c2fbeda311ce64e682a729c7c2a1f99ae847f690318e1c1abfd77a747609afe812715fb2e8f4c81845a152")
+	block, err := aes.NewCipher(keyIV[:32])
+	require.NoError(t, err)
+	expect := make([]byte, 2000)
+	cipher.NewCTR(block, keyIV[32:]).XORKeyStream(expect, expect)
+
+	s := NewAESCTR([]byte("secret"), []byte("salt"), testLabels...)
+	assert.Equal(t, expect, readN(t, s, len(expect)))
+
+	assert.Equal(t, "cc6a73fa675d8a0a955191cc5120d771c0fcf3305a8ae944a0c8a0c8cbfa0e9b",
+		hex.EncodeToString(readN(t, NewAESCTR([]byte("secret"), nil), 32)))
+}
+
+func TestStreamReadByte(t *testing.T) {
+	for _, newStream := range []func() *Stream{
+		func() *Stream { return NewHKDF([]byte("secret"), nil, testLabels...) },
+		func() *Stream { return NewAESCTR([]byte("secret"), nil, testLabels...) },
+	} {
+		expect := readN(t, newStream(), 1000)
+
+		s := newStream()
+		var got bytes.Buffer
+		for got.Len() < len(expect) {
+			if got.Len()%3 == 0 {
+				b, err := s.ReadByte()
+				require.NoError(t, err)
+				got.WriteByte(b)
+			} else {
+				got.Write(readN(t, s, min(got.Len()%50, len(expect)-got.Len())))
+			}
+		}
+		assert.Equal(t, expect, got.Bytes())
+	}
+}
+
+func TestStreamPassword(t *testing.T) {
+	gen := passit.Repeat(passit.EFFLargeWordlist, "-", 4)
+	for _, tc := range []struct {
+		r      io.Reader
+		expect string
+	}{
+		{NewHKDF([]byte("secret"), []byte("salt"), testLabels...), "cesspool-posing-pedicure-racism"},
+		{NewHKDF([]byte("secret"), []byte("salt"), Site("example.com"), User("alice"), Counter(2)), "supreme-detergent-verify-secrecy"},
+		{NewHKDF([]byte("secret"), []byte("salt"), Site("example.com"), User("bob"), Counter(1)), "support-lushness-latter-enviably"},
+		{NewAESCTR([]byte("secret"), []byte("salt"), testLabels...), "romp-vitamins-pointing-unreached"},
+		{NewAESCTR([]byte("secret"), []byte("salt"), append(testLabels, Purpose("recovery"))...), "snowsuit-outweigh-skydiver-shove"},
+	} {
+		pass, err := gen.Password(tc.r)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tc.expect, pass)
+		}
+	}
+}
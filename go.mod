@@ -3,6 +3,7 @@ module go.tmthrgd.dev/passit
 go 1.22.0
 
 require (
+	github.com/rivo/uniseg v0.4.7
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/exp v0.0.0-20241204233417-43b7b7cde48d
 	golang.org/x/text v0.21.0
@@ -47,6 +47,10 @@ type repeatGenerator struct {
 
 // Repeat returns a Generator that invokes the Generator count times and
 // concatenates the output with a fixed separator.
+//
+// If sep is empty and gen is a wordlist that is not uniquely decodable, different
+// sequences of words would produce the same password, so Password returns the
+// error from CheckDecodable.
 func Repeat(gen Generator, sep string, count int) Generator {
 	switch {
 	case count < 0:
@@ -61,6 +65,15 @@ func Repeat(gen Generator, sep string, count int) Generator {
 }
 
 func (rg *repeatGenerator) Password(r io.Reader) (string, error) {
+	if err := CheckDecodable(rg.gen, rg.sep); err != nil {
+		return "", err
+	}
+
+	return rg.join(r)
+}
+
+// join is Password without the CheckDecodable check.
+func (rg *repeatGenerator) join(r io.Reader) (string, error) {
 	parts := make([]string, rg.count)
 	for i := range parts {
 		part, err := rg.gen.Password(r)
@@ -85,6 +98,11 @@ type repeatGenGenerator struct {
 //
 // For instance, RepeatGen(gen, sep, 4) would be equivalent to
 // Join("", gen, sep, gen, sep, gen, sep, gen).
+//
+// If sep is Empty, or any other fixed empty string, and gen is a wordlist that is
+// not uniquely decodable, different sequences of words would produce the same
+// password, so Password returns the error from CheckDecodable. A sep that only
+// sometimes returns an empty string isn't checked.
 func RepeatGen(gen, sep Generator, count int) Generator {
 	switch {
 	case count < 0:
@@ -99,6 +117,12 @@ func RepeatGen(gen, sep Generator, count int) Generator {
 }
 
 func (rg *repeatGenGenerator) Password(r io.Reader) (string, error) {
+	if sep, ok := rg.sep.(fixedString); ok {
+		if err := CheckDecodable(rg.gen, string(sep)); err != nil {
+			return "", err
+		}
+	}
+
 	var b strings.Builder
 	for i := range rg.count {
 		if i > 0 {
@@ -129,6 +153,10 @@ type randomRepeatGenerator struct {
 // RandomRepeat returns a Generator that concatenates the output of invoking the
 // Generator a random number of times in [min,max] to create a single string. The
 // separator string sep is placed between the outputs in the resulting string.
+//
+// If sep is empty, max is greater than one and gen is a wordlist that is not
+// uniquely decodable, different sequences of words would produce the same
+// password, so Password returns the error from CheckDecodable.
 func RandomRepeat(gen Generator, sep string, min, max int) Generator {
 	if min < 0 {
 		panic("passit: min argument must be positive")
@@ -150,12 +178,23 @@ func RandomRepeat(gen Generator, sep string, min, max int) Generator {
 }
 
 func (rg *randomRepeatGenerator) Password(r io.Reader) (string, error) {
+	// Check before reading from r so the result doesn't depend on the number
+	// of repetitions chosen.
+	if rg.min+rg.n-1 > 1 {
+		if err := CheckDecodable(rg.gen, rg.sep); err != nil {
+			return "", err
+		}
+	}
+
 	n, err := readIntN(r, rg.n)
 	if err != nil {
 		return "", err
 	}
 
-	return Repeat(rg.gen, rg.sep, rg.min+n).Password(r)
+	// Use repeatGenerator directly rather than Repeat to avoid repeating the
+	// checks that were already performed by RandomRepeat and above.
+	rep := repeatGenerator{rg.gen, rg.sep, rg.min + n}
+	return rep.join(r)
 }
 
 type alternateGenerator struct {
@@ -209,7 +248,11 @@ func (rg *rejectionGenerator) Password(r io.Reader) (string, error) {
 	}
 }
 
-type sliceGenerator struct{ list []string }
+type sliceGenerator struct {
+	list []string
+
+	decodable decodableCache
+}
 
 // FromSlice returns a Generator that returns a random string from list.
 func FromSlice(list ...string) Generator {
@@ -219,10 +262,18 @@ func FromSlice(list ...string) Generator {
 	case 1:
 		return String(list[0])
 	default:
-		return &sliceGenerator{slices.Clone(list)}
+		return &sliceGenerator{list: slices.Clone(list)}
 	}
 }
 
+func (sg *sliceGenerator) wordlist() []string {
+	return sg.list
+}
+
+func (sg *sliceGenerator) uniquelyDecodable() bool {
+	return sg.decodable.uniquelyDecodable(sg.list)
+}
+
 func (sg *sliceGenerator) Password(r io.Reader) (string, error) {
 	return readSliceN(r, sg.list)
 }
@@ -4,6 +4,7 @@ import _ "embed" // for go:embed
 
 //go:generate go run emoji_generate.go emoji_generate_gen.go emoji_generate_ucd.go -unicode 13.0.0
 //go:generate go run emoji_generate.go emoji_generate_gen.go emoji_generate_ucd.go -unicode 15.0.0
+//go:generate go run emoji_generate.go emoji_generate_gen.go emoji_generate_ucd.go -unicode 15.1.0 -data
 
 var (
 	// Unicode13 is a list of fully-qualified emoji from Unicode 13.0.
@@ -21,4 +22,23 @@ var (
 	//
 	//go:embed emoji_15.0.txt
 	Unicode15 string
+
+	// Groups contains the emoji group and subgroup of each fully-qualified
+	// emoji. Each line contains a group, a subgroup and the space separated
+	// emoji in that subgroup, all separated by semicolons.
+	//
+	// Names contains each fully-qualified emoji and its CLDR short name,
+	// separated by a semicolon, one per line.
+	//
+	// Both are generated from the Unicode 15.1 emoji-test.txt file, see
+	// https://www.unicode.org/Public/emoji/15.1/emoji-test.txt, and are shared
+	// by Unicode13 and Unicode15. Groups, subgroups and names are those of
+	// Unicode 15.1 even for emoji from older versions, for instance 😡 is
+	// "enraged face" rather than "pouting face" and the hearts are in the
+	// "heart" subgroup.
+	//
+	//go:embed emoji_15.1_groups.txt
+	Groups string
+	//go:embed emoji_15.1_names.txt
+	Names string
 )
@@ -11,4 +11,104 @@ func TestEmojiCounts(t *testing.T) {
 	// Expected count is taken from https://www.unicode.org/Public/emoji/N.M/emoji-test.txt.
 	assert.Equal(t, 3295, strings.Count(Unicode13, "\n")+1, "Unicode 13.0")
 	assert.Equal(t, 3655, strings.Count(Unicode15, "\n")+1, "Unicode 15.0")
+
+	// 3655 from Unicode 15.0 and the 118 emoji added in 15.1.
+	assert.Equal(t, 3773, strings.Count(Names, "\n"), "names")
+}
+
+// emojiData returns the subgroup and name of each emoji in Groups and Names.
+func emojiData(t *testing.T) (subgroups, names map[string]string) {
+	subgroups, names = make(map[string]string), make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(Groups, "\n"), "\n") {
+		fields := strings.Split(line, ";")
+		if !assert.Lenf(t, fields, 3, "%q", line) {
+			continue
+		}
+
+		for _, emoji := range strings.Fields(fields[2]) {
+			_, dup := subgroups[emoji]
+			assert.Falsef(t, dup, "emoji %q is in multiple subgroups", emoji)
+			subgroups[emoji] = fields[0] + ";" + fields[1]
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(Names, "\n"), "\n") {
+		emoji, name, ok := strings.Cut(line, ";")
+		assert.Truef(t, ok, "%q", line)
+		_, dup := names[emoji]
+		assert.Falsef(t, dup, "emoji %q has multiple names", emoji)
+		names[emoji] = name
+	}
+	return subgroups, names
+}
+
+func TestEmojiGroups(t *testing.T) {
+	subgroups, names := emojiData(t)
+	assert.Len(t, subgroups, len(names), "every named emoji must be in a subgroup")
+
+	groups := make(map[string]bool)
+	for _, sg := range subgroups {
+		group, _, _ := strings.Cut(sg, ";")
+		groups[group] = true
+	}
+	assert.Len(t, groups, 9)
+
+	for _, tc := range []struct{ name, list string }{
+		{"Unicode 13.0", Unicode13},
+		{"Unicode 15.0", Unicode15},
+	} {
+		for _, emoji := range strings.Split(tc.list, "\n") {
+			_, ok := subgroups[emoji]
+			assert.Truef(t, ok, "%s: %q must be in a subgroup", tc.name, emoji)
+			_, ok = names[emoji]
+			assert.Truef(t, ok, "%s: %q must have a name", tc.name, emoji)
+		}
+	}
+
+	// The "heart" subgroup was split from "emotion" in Unicode 15.1.
+	assert.Equal(t, "Smileys & Emotion;heart", subgroups["❤️"])
+}
+
+func TestEmojiNames(t *testing.T) {
+	_, names := emojiData(t)
+
+	// The names are those of Unicode 15.1, even for older emoji.
+	assert.Equal(t, "grinning face", names["😀"])
+	assert.Equal(t, "enraged face", names["😡"])
+	assert.Equal(t, "flag: Türkiye", names["🇹🇷"])
+	assert.Equal(t, "deaf man: dark skin tone", names["🧏🏿‍♂️"])
+}
+
+func TestEmojiVersions(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		list          string
+		added, absent []string
+	}{
+		{
+			"Unicode 13.0", Unicode13,
+			// E13.0
+			[]string{"🥲", "🥷", "🫀", "🏳️‍⚧️"},
+			// E13.1, E14.0 and E15.0
+			[]string{"❤️‍🔥", "😮‍💨", "🧔‍♀️", "🫠", "🫶", "🩷", "🫨"},
+		},
+		{
+			"Unicode 15.0", Unicode15,
+			// E15.0
+			[]string{"🩷", "🫨", "🫎", "🪯"},
+			// E15.1
+			[]string{"🐦‍🔥", "🍋‍🟩", "🍄‍🟫", "⛓️‍💥", "🙂‍↔️", "🚶‍➡️", "🧑‍🧑‍🧒"},
+		},
+	} {
+		list := make(map[string]bool)
+		for _, emoji := range strings.Split(tc.list, "\n") {
+			list[emoji] = true
+		}
+
+		for _, emoji := range tc.added {
+			assert.Truef(t, list[emoji], "%s: %q is missing", tc.name, emoji)
+		}
+		for _, emoji := range tc.absent {
+			assert.Falsef(t, list[emoji], "%s: %q was added in a later version", tc.name, emoji)
+		}
+	}
 }
@@ -17,16 +17,31 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 )
 
+// data, if set, writes the group and name data rather than the emoji list.
+var data = flag.Bool("data", false, "write the emoji group and name data instead of the emoji list")
+
 func main() {
 	flag.Parse()
 	loadEmoji()
 	writeEmoji()
 }
 
-var emoji [][]rune
+// emojiGroup is a group and subgroup from emoji-test.txt along with the emoji
+// that belong to it.
+type emojiGroup struct {
+	group, subgroup string
+	emoji           []string
+}
+
+var (
+	emoji  [][]rune
+	groups []*emojiGroup
+	names  = make(map[string]string)
+)
 
 func emojiVersion() string {
 	vers := gen_UnicodeVersion()
@@ -34,26 +49,76 @@ func emojiVersion() string {
 }
 
 func loadEmoji() {
-	ucd_Parse(gen_OpenUnicodeFile("emoji", emojiVersion(), "emoji-test.txt"), func(p *ucd_Parser) {
+	var group, version string
+	r := gen_OpenUnicodeFile("emoji", emojiVersion(), "emoji-test.txt")
+	defer r.Close()
+
+	p := ucd_New(r, ucd_CommentHandler(func(s string) {
+		if v, ok := strings.CutPrefix(s, "Version:"); ok {
+			version = strings.TrimSpace(v)
+		} else if g, ok := strings.CutPrefix(s, "group:"); ok {
+			group = strings.TrimSpace(g)
+		} else if sg, ok := strings.CutPrefix(s, "subgroup:"); ok {
+			groups = append(groups, &emojiGroup{
+				group:    group,
+				subgroup: strings.TrimSpace(sg),
+			})
+		}
+	}))
+	for p.Next() {
+		// The local mirror is only keyed by the directory name, so make sure
+		// it really holds the requested version before using any of it.
+		if version != emojiVersion() {
+			log.Fatalf("emoji-test.txt is version %q, expected %q", version, emojiVersion())
+		}
+
 		if p.String(1) == "fully-qualified" {
+			checkEmojiVersion(p.Comment())
 			emoji = append(emoji, p.Runes(0))
+			names[string(p.Runes(0))] = emojiName(p.Comment())
+
+			g := groups[len(groups)-1]
+			g.emoji = append(g.emoji, string(p.Runes(0)))
 		}
-	})
+	}
+	if err := p.Err(); err != nil {
+		log.Fatal(err)
+	}
 }
 
-func writeEmoji() {
-	filename := fmt.Sprintf("emoji_%s.txt", emojiVersion())
-	f, err := os.Create(filename)
+// checkEmojiVersion ensures that the emoji described by the comment of an
+// emoji-test.txt line, like "👶🏿 E1.0 baby: dark skin tone", was added in or
+// before the version being generated.
+func checkEmojiVersion(comment string) {
+	fields := strings.SplitN(comment, " ", 3)
+	if len(fields) != 3 {
+		log.Fatalf("Unexpected emoji-test.txt comment %q", comment)
+	}
+
+	added, err := strconv.ParseFloat(strings.TrimPrefix(fields[1], "E"), 64)
 	if err != nil {
-		log.Fatalf("Could not create file %s: %v", filename, err)
+		log.Fatalf("Unexpected emoji-test.txt comment %q: %v", comment, err)
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			log.Fatalf("Could not close file %s: %v", filename, err)
-		}
-	}()
+	want, err := strconv.ParseFloat(emojiVersion(), 64)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if added > want {
+		log.Fatalf("Emoji %q from E%v is newer than %s", fields[0], added, emojiVersion())
+	}
+}
 
+// emojiName returns the CLDR short name from the comment of an emoji-test.txt
+// line, like "👶🏿 E1.0 baby: dark skin tone".
+func emojiName(comment string) string {
+	fields := strings.SplitN(comment, " ", 3)
+	if len(fields) != 3 || !strings.HasPrefix(fields[1], "E") {
+		log.Fatalf("Unexpected emoji-test.txt comment %q", comment)
+	}
+	return fields[2]
+}
+
+func writeEmoji() {
 	sort.Slice(emoji, func(i, j int) bool {
 		emojiI, emojiJ := string(emoji[i]), string(emoji[j])
 
@@ -66,6 +131,24 @@ func writeEmoji() {
 		return emojiI < emojiJ
 	})
 
+	if *data {
+		writeGroups()
+		writeNames()
+		return
+	}
+
+	filename := fmt.Sprintf("emoji_%s.txt", emojiVersion())
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatalf("Could not create file %s: %v", filename, err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			log.Fatalf("Could not close file %s: %v", filename, err)
+		}
+	}()
+
 	for i, runes := range emoji {
 		fmt.Fprint(f, string(runes))
 		if i < len(emoji)-1 {
@@ -73,3 +156,47 @@ func writeEmoji() {
 		}
 	}
 }
+
+// writeNames writes each emoji and its CLDR short name, separated by a
+// semicolon, one per line.
+func writeNames() {
+	filename := fmt.Sprintf("emoji_%s_names.txt", emojiVersion())
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatalf("Could not create file %s: %v", filename, err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			log.Fatalf("Could not close file %s: %v", filename, err)
+		}
+	}()
+
+	for _, runes := range emoji {
+		fmt.Fprintf(f, "%s;%s\n", string(runes), names[string(runes)])
+	}
+}
+
+// writeGroups writes the group data. Each line contains a group, a subgroup
+// and the space separated emoji in the subgroup, all separated by semicolons.
+func writeGroups() {
+	filename := fmt.Sprintf("emoji_%s_groups.txt", emojiVersion())
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatalf("Could not create file %s: %v", filename, err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			log.Fatalf("Could not close file %s: %v", filename, err)
+		}
+	}()
+
+	for _, g := range groups {
+		if len(g.emoji) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(f, "%s;%s;%s\n", g.group, g.subgroup, strings.Join(g.emoji, " "))
+	}
+}
@@ -78,4 +78,66 @@ var (
 	//
 	//go:embed eff_short_wordlist_2_0.txt
 	EFFShortWordlist2 string
+
+	// BIP39English is a wordlist that was taken from:
+	// https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039/english.txt.
+	//
+	// english.txt is the English wordlist from the BIP-39 specification
+	// (https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039.mediawiki). It
+	// has a SHA-256 hash of
+	// 2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda.
+	//
+	// The wordlists carry no license header of their own. They are part of the
+	// BIP-39 specification, which declares "License: MIT" in its preamble.
+	//
+	//go:embed bip39_english.txt
+	BIP39English string
+
+	// BIP39French is a wordlist that was taken from:
+	// https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039/french.txt.
+	//
+	// french.txt is the French wordlist from the BIP-39 specification. The
+	// upstream file is in Unicode Normalization Form D (NFD) and has a SHA-256
+	// hash of ebc3959ab7801a1df6bac4fa7d970652f1df76b683cd2f4003c941c63d517e59.
+	// It has been converted to Normalization Form C (NFC).
+	//
+	//go:embed bip39_french.txt
+	BIP39French string
+
+	// BIP39Spanish is a wordlist that was taken from:
+	// https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039/spanish.txt.
+	//
+	// spanish.txt is the Spanish wordlist from the BIP-39 specification. The
+	// upstream file is in Unicode Normalization Form D (NFD) and has a SHA-256
+	// hash of 46846a5a0139d1e3cb77293e521c2865f7bcdb82c44e8d0a06a2cd0ecba48c0b.
+	// It has been converted to Normalization Form C (NFC).
+	//
+	//go:embed bip39_spanish.txt
+	BIP39Spanish string
+
+	// BIP39Italian is a wordlist that was taken from:
+	// https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039/italian.txt.
+	//
+	// italian.txt is the Italian wordlist from the BIP-39 specification. It has
+	// a SHA-256 hash of
+	// d392c49fdb700a24cd1fceb237c1f65dcc128f6b34a8aacb58b59384b5c648c2.
+	//
+	//go:embed bip39_italian.txt
+	BIP39Italian string
+
+	// LexiconAdjectives, LexiconNouns, LexiconVerbs and LexiconAdverbs are lists
+	// of common English words tagged by part of speech. They were curated for
+	// passit and contain 512 adjectives, 1,024 singular nouns, 256 past tense verbs
+	// and 256 adverbs respectively.
+	//
+	// These lists are licensed under the same BSD 3-Clause license as passit.
+	//
+	//go:embed lexicon_adjectives.txt
+	LexiconAdjectives string
+	//go:embed lexicon_nouns.txt
+	LexiconNouns string
+	//go:embed lexicon_verbs.txt
+	LexiconVerbs string
+	//go:embed lexicon_adverbs.txt
+	LexiconAdverbs string
 )
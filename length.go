@@ -0,0 +1,157 @@
+package passit
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// LengthRepeat is a Generator that returns a fixed number of words whose total
+// length falls within a given range. It is created by RepeatLength.
+type LengthRepeat struct {
+	sep   string
+	count int
+
+	// byLen[l] contains the words that are l runes long.
+	byLen [][]string
+
+	// ways[k][s] is the number of sequences of k words whose lengths sum to s.
+	ways [][]*big.Int
+
+	// minSum and maxSum are the range of valid word length sums and total is
+	// the number of sequences of count words whose lengths sum to a value in
+	// that range.
+	minSum, maxSum int
+	total          *big.Int
+}
+
+// RepeatLength returns a Generator that returns count words from gen, separated
+// by sep, where the total length of the password, including separators, is
+// between minLen and maxLen runes inclusive.
+//
+// Every sequence of count words that satisfies the length constraint is equally
+// likely. Rather than generating passwords and rejecting those that are too long
+// or too short, RepeatLength counts the number of valid sequences using the
+// distribution of word lengths in the list and then selects one of them with a
+// single uniform draw from r. The entropy of the generated passwords is reported
+// by Bits.
+//
+// gen must be a wordlist accepted by AnalyzeWordlist. It returns an error if count
+// isn't positive, if minLen is greater than maxLen, if gen isn't a wordlist, if
+// sep is empty and gen isn't uniquely decodable, as Bits would then overstate the
+// entropy, or if no sequence of count words satisfies the length constraint.
+func RepeatLength(gen Generator, sep string, count, minLen, maxLen int) (*LengthRepeat, error) {
+	if count < 1 {
+		return nil, errors.New("passit: count must be positive")
+	}
+	if minLen > maxLen {
+		return nil, errors.New("passit: min argument cannot be greater than max argument")
+	}
+
+	wg, ok := gen.(wordlistGenerator)
+	if !ok {
+		return nil, errors.New("passit: generator is not a wordlist")
+	}
+
+	if err := CheckDecodable(gen, sep); err != nil {
+		return nil, err
+	}
+
+	lr := &LengthRepeat{sep: sep, count: count}
+	for _, word := range wg.wordlist() {
+		n := utf8.RuneCountInString(word)
+		for len(lr.byLen) <= n {
+			lr.byLen = append(lr.byLen, nil)
+		}
+		lr.byLen[n] = append(lr.byLen[n], word)
+	}
+
+	sepLen := (count - 1) * utf8.RuneCountInString(sep)
+	maxWordLen := len(lr.byLen) - 1
+	lr.minSum = max(minLen-sepLen, 0)
+	lr.maxSum = min(maxLen-sepLen, count*maxWordLen)
+
+	// ways[k][s] = sum over l of len(byLen[l]) * ways[k-1][s-l].
+	lr.ways = make([][]*big.Int, count+1)
+	lr.ways[0] = []*big.Int{big.NewInt(1)}
+	for k := 1; k <= count; k++ {
+		lr.ways[k] = make([]*big.Int, k*maxWordLen+1)
+		for s := range lr.ways[k] {
+			sum := new(big.Int)
+			for l, words := range lr.byLen {
+				if len(words) == 0 || s-l < 0 || s-l >= len(lr.ways[k-1]) {
+					continue
+				}
+
+				var term big.Int
+				term.Mul(big.NewInt(int64(len(words))), lr.ways[k-1][s-l])
+				sum.Add(sum, &term)
+			}
+			lr.ways[k][s] = sum
+		}
+	}
+
+	lr.total = new(big.Int)
+	for s := lr.minSum; s <= lr.maxSum; s++ {
+		lr.total.Add(lr.total, lr.ways[count][s])
+	}
+	if lr.total.Sign() == 0 {
+		return nil, errors.New("passit: no sequence of words satisfies the length constraint")
+	}
+
+	return lr, nil
+}
+
+// Bits returns the entropy, in bits, of the generated passwords.
+func (lr *LengthRepeat) Bits() float64 {
+	// total may be too large for a float64, so split it into a mantissa and
+	// exponent first.
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(lr.total).MantExp(mant)
+	f, _ := mant.Float64()
+	return math.Log2(f) + float64(exp)
+}
+
+// Password implements Generator.
+func (lr *LengthRepeat) Password(r io.Reader) (string, error) {
+	v, err := readBigIntN(r, lr.total)
+	if err != nil {
+		return "", err
+	}
+
+	// Find the sum of word lengths that v falls within.
+	s := lr.minSum
+	for ; v.Cmp(lr.ways[lr.count][s]) >= 0; s++ {
+		v.Sub(v, lr.ways[lr.count][s])
+	}
+
+	// Then decode v into a sequence of words. Within each sum, v is a mixed
+	// radix number where each digit selects a word length and a word of that
+	// length.
+	parts := make([]string, lr.count)
+	var block, idx big.Int
+	for i := range parts {
+		rest := lr.ways[lr.count-i-1]
+		for l, words := range lr.byLen {
+			if len(words) == 0 || s-l < 0 || s-l >= len(rest) || rest[s-l].Sign() == 0 {
+				continue
+			}
+
+			block.Mul(big.NewInt(int64(len(words))), rest[s-l])
+			if v.Cmp(&block) >= 0 {
+				v.Sub(v, &block)
+				continue
+			}
+
+			idx.QuoRem(v, rest[s-l], v)
+			parts[i] = words[idx.Int64()]
+			s -= l
+			break
+		}
+	}
+
+	return strings.Join(parts, lr.sep), nil
+}
@@ -0,0 +1,98 @@
+package passit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatLength(t *testing.T) {
+	_, err := RepeatLength(EFFLargeWordlist, " ", 0, 1, 10)
+	assert.EqualError(t, err, "passit: count must be positive")
+
+	_, err = RepeatLength(EFFLargeWordlist, " ", 4, 10, 1)
+	assert.EqualError(t, err, "passit: min argument cannot be greater than max argument")
+
+	_, err = RepeatLength(Digit, " ", 4, 1, 10)
+	assert.EqualError(t, err, "passit: generator is not a wordlist")
+
+	_, err = RepeatLength(EFFLargeWordlist, " ", 4, 1, 10)
+	assert.EqualError(t, err, "passit: no sequence of words satisfies the length constraint")
+
+	_, err = RepeatLength(STS10Wordlist, "", 4, 1, 40)
+	assert.EqualError(t, err, "passit: wordlist is not uniquely decodable and must be used with a separator")
+
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		sep    string
+		count  int
+		minLen int
+		maxLen int
+		bits   float64
+		expect string
+	}{
+		{"EFFLargeWordlist", EFFLargeWordlist, "-", 6, 1, 30, 60.677, "icy-grit-spur-pull-body-frenzy"},
+		{"EFFLargeWordlist exact", EFFLargeWordlist, "-", 6, 40, 40, 71.946, "lavish-eaten-mutt-cheesy-scraggly-polish"},
+		{"EFFLargeWordlist unconstrained", EFFLargeWordlist, " ", 4, 0, 1000, 51.699, "cash efficient payable cardinal"},
+		{"OrchardStreetLongV2", OrchardStreetLongV2, "", 4, 20, 24, 52.071, "vasebenefitwarmthmutants"},
+	} {
+		lr, err := RepeatLength(tc.gen, tc.sep, tc.count, tc.minLen, tc.maxLen)
+		if !assert.NoErrorf(t, err, "RepeatLength: %s", tc.name) {
+			continue
+		}
+
+		assert.InDeltaf(t, tc.bits, lr.Bits(), 0.0005, "Bits: %s", tc.name)
+
+		tr := newTestRand()
+
+		pass, err := lr.Password(tr)
+		if assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+		}
+
+		for range 100 {
+			pass, err := lr.Password(tr)
+			if !assert.NoErrorf(t, err, "Password: %s", tc.name) {
+				break
+			}
+
+			n := utf8.RuneCountInString(pass)
+			assert.Truef(t, n >= tc.minLen && n <= tc.maxLen,
+				"len(%q) = %d, not in [%d,%d]: %s", pass, n, tc.minLen, tc.maxLen, tc.name)
+		}
+	}
+}
+
+func TestRepeatLengthUniform(t *testing.T) {
+	lr, err := RepeatLength(FromSlice("a", "bb", "ccc", "dd"), "-", 2, 4, 5)
+	require.NoError(t, err)
+
+	// The valid sequences are: a-bb, a-dd, bb-a, dd-a, a-ccc, bb-bb, bb-dd,
+	// ccc-a, dd-bb and dd-dd.
+	assert.InDelta(t, 3.322, lr.Bits(), 0.0005)
+
+	seen := make(map[string]bool)
+	for v := range 10 {
+		pass, err := lr.Password(bytes.NewReader([]byte{byte(v)}))
+		require.NoError(t, err)
+
+		assert.Falsef(t, seen[pass], "%q generated twice", pass)
+		seen[pass] = true
+
+		n := len(pass)
+		assert.Truef(t, n >= 4 && n <= 5, "len(%q) = %d", pass, n)
+	}
+	assert.Len(t, seen, 10)
+
+	pass, err := lr.Password(bytes.NewReader([]byte{10}))
+	require.NoError(t, err)
+	assert.Equal(t, "a-bb", pass, "v=10 should wrap around to v=0")
+
+	_, err = lr.Password(strings.NewReader(""))
+	assert.ErrorContains(t, err, "passit: failed to read entropy: ")
+}
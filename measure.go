@@ -0,0 +1,98 @@
+package passit
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// LengthMeasure is a unit that the length of a password can be measured in.
+type LengthMeasure int
+
+const (
+	// LengthBytes measures length in bytes of UTF-8. Invalid UTF-8 is counted
+	// byte for byte like any other bytes.
+	LengthBytes LengthMeasure = iota
+	// LengthRunes measures length in Unicode code points. Each byte of invalid
+	// UTF-8 counts as one code point, as if it were replaced by U+FFFD.
+	LengthRunes
+	// LengthGraphemes measures length in extended grapheme clusters as defined by
+	// Unicode Standard Annex #29. This is the number of user-perceived
+	// characters, so each emoji counts as one regardless of how many code points
+	// it contains. Each byte of invalid UTF-8 is segmented as if it were
+	// replaced by U+FFFD.
+	LengthGraphemes
+	// LengthUTF16 measures length in UTF-16 code units. Code points outside the
+	// Basic Multilingual Plane, which includes most emoji, count as two. This is
+	// how JavaScript's String.prototype.length and the HTML maxlength and
+	// minlength attributes measure length. Each byte of invalid UTF-8 counts as
+	// one code unit, as if it were replaced by U+FFFD.
+	LengthUTF16
+)
+
+// Len returns the length of s in the unit of m. See each LengthMeasure for how
+// invalid UTF-8 is measured.
+func (m LengthMeasure) Len(s string) int {
+	switch m {
+	case LengthBytes:
+		return len(s)
+	case LengthRunes:
+		return utf8.RuneCountInString(s)
+	case LengthGraphemes:
+		return uniseg.GraphemeClusterCount(s)
+	case LengthUTF16:
+		var n int
+		for _, r := range s {
+			if r >= 0x10000 {
+				n += 2
+			} else {
+				n++
+			}
+		}
+		return n
+	default:
+		panic("passit: invalid length measure")
+	}
+}
+
+// String returns the name of the unit, like "bytes".
+func (m LengthMeasure) String() string {
+	switch m {
+	case LengthBytes:
+		return "bytes"
+	case LengthRunes:
+		return "runes"
+	case LengthGraphemes:
+		return "graphemes"
+	case LengthUTF16:
+		return "UTF-16 code units"
+	default:
+		return fmt.Sprintf("LengthMeasure(%d)", int(m))
+	}
+}
+
+// ConstrainLength returns a Generator that continually generates passwords with
+// gen until the length of the password, as measured by m, is between minLen and
+// maxLen inclusive.
+//
+// Every password gen can produce that satisfies the constraint is returned with
+// the same relative probability as it would be by gen. It can be used to meet the
+// length limits of a login form with RandomRepeat, emoji or RegexpParser
+// generators, where the number of bytes or UTF-16 code units in each rune or
+// emoji varies. For wordlists with a fixed number of words, RepeatLength is more
+// efficient and reports the resulting entropy.
+//
+// As with RejectionSample, the behaviour is unspecified if gen never produces a
+// password of a suitable length.
+func ConstrainLength(gen Generator, m LengthMeasure, minLen, maxLen int) Generator {
+	if minLen > maxLen {
+		panic("passit: min argument cannot be greater than max argument")
+	}
+	m.Len("") // Panic early on an invalid measure.
+
+	return RejectionSample(gen, func(pass string) bool {
+		n := m.Len(pass)
+		return n >= minLen && n <= maxLen
+	})
+}
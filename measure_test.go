@@ -0,0 +1,94 @@
+package passit
+
+import (
+	"regexp/syntax"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthMeasure(t *testing.T) {
+	for _, tc := range []struct {
+		s                              string
+		bytes, runes, graphemes, utf16 int
+	}{
+		{"", 0, 0, 0, 0},
+		{"password", 8, 8, 8, 8},
+		{"évasion", 8, 7, 7, 7},
+		{"évasion", 9, 8, 7, 8},
+		{"⌚", 3, 1, 1, 1},
+		{"😀", 4, 1, 1, 2},
+		{"🏎️", 7, 2, 1, 3},
+		{"🧏🏿‍♂️", 17, 5, 1, 7},
+		{"🇳🇿", 8, 2, 1, 4},
+		{"1️⃣", 7, 3, 1, 3},
+		{"a🧏🏿‍♂️b", 19, 7, 3, 9},
+		{"\xff", 1, 1, 1, 1},
+		{"\xff\xfe", 2, 2, 2, 2},
+		{"\xe2\x82", 2, 2, 2, 2},
+		{"\xff\u0301", 3, 2, 1, 2},
+	} {
+		assert.Equalf(t, tc.bytes, LengthBytes.Len(tc.s), "LengthBytes.Len(%q)", tc.s)
+		assert.Equalf(t, tc.runes, LengthRunes.Len(tc.s), "LengthRunes.Len(%q)", tc.s)
+		assert.Equalf(t, tc.graphemes, LengthGraphemes.Len(tc.s), "LengthGraphemes.Len(%q)", tc.s)
+		assert.Equalf(t, tc.utf16, LengthUTF16.Len(tc.s), "LengthUTF16.Len(%q)", tc.s)
+	}
+
+	assert.Equal(t, "bytes", LengthBytes.String())
+	assert.Equal(t, "UTF-16 code units", LengthUTF16.String())
+	assert.Equal(t, "LengthMeasure(7)", LengthMeasure(7).String())
+	assert.PanicsWithValue(t, "passit: invalid length measure", func() {
+		LengthMeasure(7).Len("")
+	})
+}
+
+func TestConstrainLength(t *testing.T) {
+	assert.PanicsWithValue(t, "passit: min argument cannot be greater than max argument", func() {
+		ConstrainLength(Emoji15, LengthUTF16, 10, 1)
+	})
+	assert.PanicsWithValue(t, "passit: invalid length measure", func() {
+		ConstrainLength(Emoji15, LengthMeasure(-1), 1, 10)
+	})
+
+	var p RegexpParser
+	p.SetSpecialCapture("emoji", SpecialCaptureBasic(Emoji15))
+	re, err := p.Parse(`[[:alnum:]]{4,12}(?P<emoji>){2,4}`, syntax.Perl)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name           string
+		gen            Generator
+		m              LengthMeasure
+		minLen, maxLen int
+		expect         string
+	}{
+		{"Emoji15 UTF-16", Repeat(Emoji15, "", 4), LengthUTF16, 8, 12, "👳🏿🌀🦿👈🏽"},
+		{"Emoji15 graphemes", RandomRepeat(Emoji15, "", 1, 16), LengthGraphemes, 6, 6, "🇦🇲💙👐💪🏾🫁👱🏻"},
+		{"Emoji15 bytes", RandomRepeat(Emoji15, "", 1, 16), LengthBytes, 20, 24, "👈🏽🇸🇪💂🏻"},
+		{"RandomRepeat runes", RandomRepeat(EFFShortWordlist1, "-", 1, 10), LengthRunes, 20, 20, "dash-chute-dove-ooze"},
+		{"RandomRepeat UTF-16", RandomRepeat(ASCIIGraphic, "", 1, 64), LengthUTF16, 16, 16, "w}S^H30R:[gZ[/|i"},
+		{"Regexp UTF-16", re, LengthUTF16, 12, 12, "QID994Ft🫕🥭"},
+		{"Regexp graphemes", re, LengthGraphemes, 8, 8, "K2mZs👔🟨↗️"},
+	} {
+		gen := ConstrainLength(tc.gen, tc.m, tc.minLen, tc.maxLen)
+		tr := newTestRand()
+
+		pass, err := gen.Password(tr)
+		if !assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			continue
+		}
+		assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+
+		for range 100 {
+			pass, err := gen.Password(tr)
+			if !assert.NoErrorf(t, err, "Password: %s", tc.name) {
+				break
+			}
+
+			n := tc.m.Len(pass)
+			assert.GreaterOrEqualf(t, n, tc.minLen, "Len(%q): %s", pass, tc.name)
+			assert.LessOrEqualf(t, n, tc.maxLen, "Len(%q): %s", pass, tc.name)
+		}
+	}
+}
@@ -24,6 +24,19 @@
 // Note: Wrapping the [io.Reader] with [bufio.NewReader] (if it doesn't already
 // implement [io.ByteReader]) will greatly improve the performance of the
 // generators.
+//
+// If the [io.Reader] also implements [Source], generators draw random integers
+// from it directly rather than reading bytes. This is faster and wastes less
+// randomness, but has its own determinism contract: see [Source].
+// [NewChaCha8Source] and [NewCryptoSource] return readers that implement it.
+//
+// Wrapping the [io.Reader] with [NewBitReader] makes generators read close to the
+// minimum number of bits needed instead of whole bytes per random integer. Each
+// way of reading randomness has a [Determinism] that identifies how the input is
+// mapped to passwords; [DeterminismOf] reports the one used for a reader.
+//
+// The output of the generators returned by [Stable] is frozen for each
+// [Determinism] version, from the release that introduced them onwards.
 package passit
 
 import "io"
@@ -4,7 +4,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"math/bits"
+	"slices"
 )
 
 func wrapReadError(err error) error {
@@ -37,7 +40,9 @@ func readUint64Byte(br io.ByteReader, byteLen int) (uint64, error) {
 	return v, nil
 }
 
-// readUint64n is a helper function that should only be called by readIntN.
+// readUint64n is a helper function that should only be called by readIntN and
+// readBigIntN. bitLen must be a multiple of 8 no greater than 64 and n must be
+// less than 1<<bitLen.
 func readUint64n(r io.Reader, n uint64, bitLen int) (v uint64, err error) {
 	// This is based on golang.org/x/exp/rand:
 	// https://github.com/golang/exp/blob/ec7cb31e5a562f5e9e31b300128d2f530f55d127/rand/rand.go#L91-L109.
@@ -92,6 +97,10 @@ func readIntN(r io.Reader, n int) (int, error) {
 		return 0, nil
 	}
 
+	if s, ok := samplerOf(r); ok {
+		return s.sampleIntN(n)
+	}
+
 	// Round up to the nearest multiple of 8 (i.e. 8, 16, 24, 32, 40, 48, 56 or 64).
 	bitLen := (bits.Len(uint(n)) + 7) &^ 7
 
@@ -107,3 +116,110 @@ func readSliceN[T any](r io.Reader, s []T) (T, error) {
 
 	return s[i], nil
 }
+
+// readBigIntN returns a uniform random value in [0,n). It reads little-endian
+// values in the same manner as readUint64n, rejecting values that would
+// introduce bias.
+func readBigIntN(r io.Reader, n *big.Int) (*big.Int, error) {
+	switch n.Sign() {
+	case -1, 0:
+		panic("passit: invalid argument to readBigIntN")
+	}
+	if n.IsInt64() && n.Int64() == 1 {
+		return new(big.Int), nil
+	}
+
+	if s, ok := samplerOf(r); ok {
+		return readBigIntNSampler(s, n)
+	}
+
+	byteLen := (n.BitLen() + 7) / 8
+	if n.IsUint64() {
+		v, err := readUint64n(r, n.Uint64(), byteLen*8)
+		return new(big.Int).SetUint64(v), err
+	}
+
+	// max is the maximum value that can be read, 1<<(byteLen*8) - 1, and
+	// ceiling is the largest multiple of n not greater than max+1.
+	max := new(big.Int).Lsh(big.NewInt(1), uint(byteLen*8))
+	ceiling := new(big.Int).Sub(max, new(big.Int).Mod(max, n))
+
+	buf := make([]byte, byteLen)
+	v := new(big.Int)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, wrapReadError(err)
+		}
+
+		slices.Reverse(buf)
+		if v.SetBytes(buf).Cmp(ceiling) < 0 {
+			return v.Mod(v, n), nil
+		}
+	}
+}
+
+// sampler is implemented by readers that sample uniform values themselves rather
+// than providing bytes to readUint64n.
+type sampler interface {
+	sampleIntN(n int) (int, error)
+	sampleUint64() (uint64, error)
+}
+
+// sourceSampler adapts a Source into a sampler.
+type sourceSampler struct{ src Source }
+
+func (s sourceSampler) sampleIntN(n int) (int, error) {
+	return s.src.IntN(n), nil
+}
+
+func (s sourceSampler) sampleUint64() (uint64, error) {
+	return s.src.Uint64(), nil
+}
+
+// samplerOf returns the sampler for r, if r is a sampler or implements Source.
+func samplerOf(r io.Reader) (sampler, bool) {
+	switch r := r.(type) {
+	case sampler:
+		return r, true
+	case Source:
+		return sourceSampler{r}, true
+	default:
+		return nil, false
+	}
+}
+
+// readBigIntNSampler returns a uniform random value in [0,n) from s. Values that
+// fit in an int are drawn with sampleIntN, larger values by masking successive
+// sampleUint64 values to the bit length of n and rejecting those not less than n.
+func readBigIntNSampler(s sampler, n *big.Int) (*big.Int, error) {
+	if n.IsInt64() && n.Int64() <= math.MaxInt {
+		v, err := s.sampleIntN(int(n.Int64()))
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(v)), nil
+	}
+
+	bitLen := n.BitLen()
+	words := make([]big.Word, (bitLen+bits.UintSize-1)/bits.UintSize)
+	top := big.Word(1)<<(bitLen%bits.UintSize) - 1
+	if bitLen%bits.UintSize == 0 {
+		top = ^big.Word(0)
+	}
+
+	v := new(big.Int)
+	for {
+		for i := range words {
+			w, err := s.sampleUint64()
+			if err != nil {
+				return nil, err
+			}
+			words[i] = big.Word(w)
+		}
+		words[len(words)-1] &= top
+
+		if v.SetBits(words).Cmp(n) < 0 {
+			return v, nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package passit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// DefaultSentenceTemplate is a sentence template that uses each of the default
+// sentence slots once. It has 35.000 bits of entropy.
+const DefaultSentenceTemplate = "the {adj} {noun} {verb} {adv}"
+
+// Sentence is a Generator that returns a sentence-like passphrase by filling the
+// slots of a template with random words. It is created by NewSentence.
+type Sentence struct {
+	parts []sentencePart
+	bits  float64
+}
+
+// sentencePart is either literal text from the template or a slot to be filled
+// by gen.
+type sentencePart struct {
+	literal string
+	gen     Generator
+}
+
+// NewSentence returns a Generator that fills the slots of template with random
+// words.
+//
+// A slot is written as a name in braces, like {noun}. All other text in the
+// template is copied to the output unchanged; {{ and }} can be used to include a
+// literal brace. The following slots are always available:
+//
+//	{adj}  a word from LexiconAdjective
+//	{noun} a word from LexiconNoun
+//	{verb} a word from LexiconVerb
+//	{adv}  a word from LexiconAdverb
+//
+// Additional slots, or replacements for the slots above, can be provided in slots.
+// Each slot Generator must be a wordlist accepted by AnalyzeWordlist so that the
+// entropy of the sentence can be calculated. slots may be nil.
+//
+// Each slot is filled with a word selected uniformly at random and independently
+// of the other slots. A word listed more than once in a slot's wordlist is only
+// counted once. The entropy of the generated sentences is reported by Bits.
+// It assumes that distinct choices of words produce distinct sentences, which is
+// true if no two slots are adjacent and no word contains the text that follows
+// its slot.
+func NewSentence(template string, slots map[string]Generator) (*Sentence, error) {
+	s := new(Sentence)
+
+	var lit strings.Builder
+	for rest := template; rest != ""; {
+		i := strings.IndexAny(rest, "{}")
+		if i < 0 {
+			lit.WriteString(rest)
+			break
+		}
+
+		lit.WriteString(rest[:i])
+		c, rest0 := rest[i], rest[i+1:]
+		if rest0 != "" && rest0[0] == c {
+			lit.WriteByte(c)
+			rest = rest0[1:]
+			continue
+		}
+		if c == '}' {
+			return nil, errors.New("passit: unexpected } in sentence template")
+		}
+
+		name, after, ok := strings.Cut(rest0, "}")
+		if !ok {
+			return nil, errors.New("passit: unterminated slot in sentence template")
+		}
+		rest = after
+
+		gen, ok := slots[name]
+		if !ok {
+			gen, ok = defaultSentenceSlot(name)
+		}
+		if !ok {
+			return nil, fmt.Errorf("passit: unknown sentence slot %q", name)
+		}
+
+		wg, ok := gen.(wordlistGenerator)
+		if !ok {
+			return nil, fmt.Errorf("passit: sentence slot %q is not a wordlist", name)
+		}
+
+		// A word that is listed more than once would be selected more often than
+		// the others, so duplicates are removed before sampling.
+		words := wg.wordlist()
+		if unique := uniqueWords(words); len(unique) < len(words) {
+			words, gen = unique, &sliceGenerator{list: unique}
+		}
+		s.bits += math.Log2(float64(len(words)))
+
+		if lit.Len() > 0 {
+			s.parts = append(s.parts, sentencePart{literal: lit.String()})
+			lit.Reset()
+		}
+		s.parts = append(s.parts, sentencePart{gen: gen})
+	}
+	if lit.Len() > 0 {
+		s.parts = append(s.parts, sentencePart{literal: lit.String()})
+	}
+
+	return s, nil
+}
+
+// uniqueWords returns the words of list in order, without any repeated words.
+// It returns list itself if it contains no duplicates.
+func uniqueWords(list []string) []string {
+	seen := make(map[string]struct{}, len(list))
+	for i, word := range list {
+		if _, dup := seen[word]; !dup {
+			seen[word] = struct{}{}
+			continue
+		}
+
+		unique := slices.Clone(list[:i])
+		for _, word := range list[i+1:] {
+			if _, dup := seen[word]; !dup {
+				seen[word] = struct{}{}
+				unique = append(unique, word)
+			}
+		}
+		return unique
+	}
+	return list
+}
+
+func defaultSentenceSlot(name string) (Generator, bool) {
+	switch name {
+	case "adj":
+		return LexiconAdjective, true
+	case "noun":
+		return LexiconNoun, true
+	case "verb":
+		return LexiconVerb, true
+	case "adv":
+		return LexiconAdverb, true
+	default:
+		return nil, false
+	}
+}
+
+// Bits returns the entropy, in bits, of the generated sentences.
+func (s *Sentence) Bits() float64 {
+	return s.bits
+}
+
+// Password implements Generator.
+func (s *Sentence) Password(r io.Reader) (string, error) {
+	var b strings.Builder
+	for _, part := range s.parts {
+		if part.gen == nil {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		word, err := part.gen.Password(r)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(word)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,106 @@
+package passit
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentence(t *testing.T) {
+	colours := FromSlice("red", "green", "blue", "yellow")
+
+	for _, tc := range []struct {
+		template string
+		slots    map[string]Generator
+		bits     float64
+		expect   string
+	}{
+		{DefaultSentenceTemplate, nil, 35, "the prickly bathtub swerved clumsily"},
+		{"{adj} {noun}", nil, 19, "prickly bathtub"},
+		{"{noun}s {verb} {adv}.", nil, 26, "engines doodled valiantly."},
+		{"a {colour} {noun}", map[string]Generator{"colour": colours}, 12, "a blue weasel"},
+		{"{adj} {adj}", map[string]Generator{"adj": colours}, 4, "blue green"},
+		{"{{literal}} {noun}", nil, 10, "{literal} engine"},
+		{"no slots", nil, 0, "no slots"},
+		{"", nil, 0, ""},
+	} {
+		s, err := NewSentence(tc.template, tc.slots)
+		if !assert.NoErrorf(t, err, "NewSentence(%q)", tc.template) {
+			continue
+		}
+
+		assert.InDeltaf(t, tc.bits, s.Bits(), 1e-9, "Bits: %q", tc.template)
+
+		pass, err := s.Password(newTestRand())
+		if assert.NoErrorf(t, err, "Password: %q", tc.template) {
+			assert.Equalf(t, tc.expect, pass, "Password: %q", tc.template)
+		}
+	}
+}
+
+func TestSentenceDuplicates(t *testing.T) {
+	dups := FromSlice("red", "red", "green", "red", "blue", "green")
+	s, err := NewSentence("a {colour}", map[string]Generator{"colour": dups})
+	require.NoError(t, err)
+	assert.InDelta(t, math.Log2(3), s.Bits(), 1e-9)
+
+	// The duplicates are removed, so the sentence samples like the unique list.
+	unique, err := NewSentence("a {colour}", map[string]Generator{
+		"colour": FromSlice("red", "green", "blue"),
+	})
+	require.NoError(t, err)
+
+	r1, r2 := newTestRand(), newTestRand()
+	for range 100 {
+		pass1, err := s.Password(r1)
+		require.NoError(t, err)
+		pass2, err := unique.Password(r2)
+		require.NoError(t, err)
+		assert.Equal(t, pass2, pass1)
+	}
+
+	assert.Equal(t, []string{"a", "b"}, uniqueWords([]string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b", "c"}, uniqueWords([]string{"a", "b", "a", "c", "b"}))
+}
+
+func TestSentenceErrors(t *testing.T) {
+	for _, tc := range []struct {
+		template string
+		slots    map[string]Generator
+		err      string
+	}{
+		{"the {adj", nil, "passit: unterminated slot in sentence template"},
+		{"the adj}", nil, "passit: unexpected } in sentence template"},
+		{"the {colour}", nil, `passit: unknown sentence slot "colour"`},
+		{"the {}", nil, `passit: unknown sentence slot ""`},
+		{"the {pin}", map[string]Generator{"pin": Digit}, `passit: sentence slot "pin" is not a wordlist`},
+	} {
+		_, err := NewSentence(tc.template, tc.slots)
+		assert.EqualErrorf(t, err, tc.err, "NewSentence(%q)", tc.template)
+	}
+}
+
+func TestLexicon(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		gen  Generator
+		len  int
+	}{
+		{"LexiconAdjective", LexiconAdjective, 512},
+		{"LexiconNoun", LexiconNoun, 1024},
+		{"LexiconVerb", LexiconVerb, 256},
+		{"LexiconAdverb", LexiconAdverb, 256},
+	} {
+		list := tc.gen.(wordlistGenerator).wordlist()
+		assert.Lenf(t, list, tc.len, "%s", tc.name)
+		assert.Truef(t, slices.IsSorted(list), "%s must be sorted", tc.name)
+		assert.Lenf(t, slices.Compact(slices.Clone(list)), tc.len, "%s must be unique", tc.name)
+
+		for _, word := range list {
+			assert.Regexpf(t, "^[a-z]+$", word, "%s", tc.name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package passit
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	mathrand "math/rand/v2"
+)
+
+// Source is an optional interface that can be implemented by the io.Reader passed
+// to Generator.Password. When r implements Source, generators draw random integers
+// directly from IntN instead of reading and rejecting whole bytes, which is
+// faster and wastes less randomness. Generators that consume raw bytes, like the
+// encoding generators and BIP39Mnemonic, still call Read.
+//
+// The determinism contract for a Source is separate from the byte stream
+// contract. A Source produces the same passwords as another Source that returns
+// the same sequence of values from IntN and Uint64, but passwords generated from a
+// Source differ from those generated by reading the same bytes through Read alone.
+// In particular, wrapping a deterministic Source in another io.Reader, like
+// bufio.Reader, changes the generated passwords.
+type Source interface {
+	// Uint64 returns a uniformly distributed 64-bit value.
+	Uint64() uint64
+
+	// IntN returns a uniformly distributed value in [0,n). It panics if n <= 0.
+	IntN(n int) int
+}
+
+// RandSource adapts a math/rand/v2 Source into an io.Reader that implements Source
+// and io.ByteReader. It is created by NewRandSource, NewChaCha8Source or
+// NewCryptoSource.
+//
+// IntN uses the algorithm of (*math/rand/v2.Rand).IntN. Read returns the
+// little-endian bytes of successive calls to Uint64. A RandSource is not safe for
+// concurrent use.
+type RandSource struct {
+	rand *mathrand.Rand
+
+	// buf holds the unread bytes of the last Uint64 value used by Read.
+	buf [8]byte
+	off int
+}
+
+// NewRandSource returns a RandSource that draws from src.
+//
+// Only use a cryptographically secure src, like math/rand/v2.ChaCha8 with a
+// secret seed.
+func NewRandSource(src mathrand.Source) *RandSource {
+	return &RandSource{rand: mathrand.New(src), off: 8}
+}
+
+// NewChaCha8Source returns a RandSource that draws from a math/rand/v2.ChaCha8
+// seeded with seed. It is deterministic: the same seed always produces the same
+// passwords.
+func NewChaCha8Source(seed [32]byte) *RandSource {
+	return NewRandSource(mathrand.NewChaCha8(seed))
+}
+
+// NewCryptoSource returns a RandSource that draws from crypto/rand.Reader. It
+// panics if reading from crypto/rand.Reader fails.
+func NewCryptoSource() *RandSource {
+	return NewRandSource(cryptoSource{})
+}
+
+// Uint64 implements Source.
+func (rs *RandSource) Uint64() uint64 {
+	return rs.rand.Uint64()
+}
+
+// IntN implements Source.
+func (rs *RandSource) IntN(n int) int {
+	return rs.rand.IntN(n)
+}
+
+// Read implements io.Reader.
+func (rs *RandSource) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i], _ = rs.ReadByte()
+	}
+
+	return len(p), nil
+}
+
+// ReadByte implements io.ByteReader.
+func (rs *RandSource) ReadByte() (byte, error) {
+	if rs.off == len(rs.buf) {
+		binary.LittleEndian.PutUint64(rs.buf[:], rs.rand.Uint64())
+		rs.off = 0
+	}
+
+	b := rs.buf[rs.off]
+	rs.off++
+	return b, nil
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var buf [8]byte
+	if _, err := io.ReadFull(rand.Reader, buf[:]); err != nil {
+		panic("passit: failed to read from crypto/rand: " + err.Error())
+	}
+
+	return binary.LittleEndian.Uint64(buf[:])
+}
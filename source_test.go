@@ -0,0 +1,102 @@
+package passit
+
+import (
+	"bufio"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaCha8Source(t *testing.T) {
+	seed := [32]byte([]byte("chacha8 seed for passit testing!"))
+
+	lr, err := RepeatLength(EFFLargeWordlist, "-", 8, 40, 48)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		expect string
+	}{
+		{"Digit", Repeat(Digit, "", 12), "986126737609"},
+		{"EFFLargeWordlist", Repeat(EFFLargeWordlist, "-", 6), "unsubtly-tigress-ranked-corner-dimmed-remember"},
+		{"Emoji15", Repeat(Emoji15, "", 4), "🧑🏻\u200d🤝\u200d🧑🏽🦹🏼\u200d♀️👨\u200d✈️🕗"},
+		{"HexLower", HexLower(8), "600401b879aab6f0"},
+		{"SpectreLong", SpectreLong, "XofgSoko6@Zejw"},
+		{"RepeatLength", lr, "deed-feed-hula-unlovely-juice-gander-musty-oozy"},
+	} {
+		pass, err := tc.gen.Password(NewChaCha8Source(seed))
+		if !assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			continue
+		}
+		assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+
+		// The byte stream path produces different passwords from the same
+		// source.
+		pass2, err := tc.gen.Password(bufio.NewReader(NewChaCha8Source(seed)))
+		if assert.NoErrorf(t, err, "Password: %s", tc.name) && tc.name != "HexLower" {
+			assert.NotEqualf(t, pass, pass2, "Password: %s", tc.name)
+		}
+	}
+}
+
+func TestCryptoSource(t *testing.T) {
+	r := NewCryptoSource()
+	for range 100 {
+		pass, err := Repeat(Digit, "", 20).Password(r)
+		require.NoError(t, err)
+		assert.Len(t, pass, 20)
+	}
+
+	var buf [64]byte
+	n, err := r.Read(buf[:])
+	assert.NoError(t, err)
+	assert.Equal(t, len(buf), n)
+	assert.NotEqual(t, [64]byte{}, buf)
+}
+
+// recordingSource is a Source that returns fixed values and records the
+// arguments to IntN.
+type recordingSource struct {
+	uint64s []uint64
+	intNs   []int
+}
+
+func (rs *recordingSource) Read([]byte) (int, error) {
+	panic("Read should not be called")
+}
+
+func (rs *recordingSource) Uint64() uint64 {
+	v := rs.uint64s[0]
+	rs.uint64s = rs.uint64s[1:]
+	return v
+}
+
+func (rs *recordingSource) IntN(n int) int {
+	rs.intNs = append(rs.intNs, n)
+	return n - 1
+}
+
+func TestSourceIntN(t *testing.T) {
+	rs := new(recordingSource)
+	pass, err := Join(" ", Repeat(Digit, "", 3), EFFLargeWordlist, LatinLower).Password(rs)
+	require.NoError(t, err)
+	assert.Equal(t, "999 zoom z", pass)
+	assert.Equal(t, []int{10, 10, 10, 7776, 26}, rs.intNs)
+
+	// Values that don't fit in an int are drawn by masking and rejecting
+	// Uint64 values.
+	n := new(big.Int).Lsh(big.NewInt(3), 64) // 3 * 2^64, 66 bits
+	rs = &recordingSource{uint64s: []uint64{
+		5, ^uint64(0), // 3*2^64 + 5 after masking, rejected
+		7, 2, // 2*2^64 + 7, accepted
+	}}
+	v, err := readBigIntN(rs, n)
+	require.NoError(t, err)
+	expect := new(big.Int).Lsh(big.NewInt(2), 64)
+	expect.Add(expect, big.NewInt(7))
+	assert.Equal(t, expect, v)
+	assert.Empty(t, rs.uint64s)
+}
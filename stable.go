@@ -0,0 +1,131 @@
+package passit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+
+	"golang.org/x/exp/utf8string"
+)
+
+// stableGenerators are the package level generators whose output is frozen from
+// the release that introduced Stable onwards. It must only ever be added to.
+var stableGenerators = map[string]Generator{
+	"Empty":  Empty,
+	"Space":  Space,
+	"Hyphen": Hyphen,
+
+	"Digit":                 Digit,
+	"LatinLower":            LatinLower,
+	"LatinUpper":            LatinUpper,
+	"LatinMixed":            LatinMixed,
+	"LatinLowerDigit":       LatinLowerDigit,
+	"LatinUpperDigit":       LatinUpperDigit,
+	"LatinMixedDigit":       LatinMixedDigit,
+	"ASCIINoLettersNumbers": ASCIINoLettersNumbers,
+	"ASCIINoLetters":        ASCIINoLetters,
+	"ASCIIGraphic":          ASCIIGraphic,
+
+	"OrchardStreetMedium":   OrchardStreetMedium,
+	"OrchardStreetLong":     OrchardStreetLong,
+	"OrchardStreetAlpha":    OrchardStreetAlpha,
+	"OrchardStreetQWERTY":   OrchardStreetQWERTY,
+	"OrchardStreetMediumV2": OrchardStreetMediumV2,
+	"OrchardStreetLongV2":   OrchardStreetLongV2,
+	"OrchardStreetAlphaV2":  OrchardStreetAlphaV2,
+	"OrchardStreetQWERTYV2": OrchardStreetQWERTYV2,
+	"STS10Wordlist":         STS10Wordlist,
+	"EFFLargeWordlist":      EFFLargeWordlist,
+	"EFFShortWordlist1":     EFFShortWordlist1,
+	"EFFShortWordlist2":     EFFShortWordlist2,
+	"BIP39English":          BIP39English,
+	"BIP39French":           BIP39French,
+	"BIP39FrenchASCII":      BIP39FrenchASCII,
+	"BIP39Spanish":          BIP39Spanish,
+	"BIP39SpanishASCII":     BIP39SpanishASCII,
+	"BIP39Italian":          BIP39Italian,
+	"LexiconAdjective":      LexiconAdjective,
+	"LexiconNoun":           LexiconNoun,
+	"LexiconVerb":           LexiconVerb,
+	"LexiconAdverb":         LexiconAdverb,
+
+	"Emoji13": Emoji13,
+	"Emoji15": Emoji15,
+
+	"SpectreMaximum": SpectreMaximum,
+	"SpectreLong":    SpectreLong,
+	"SpectreMedium":  SpectreMedium,
+	"SpectreBasic":   SpectreBasic,
+	"SpectreShort":   SpectreShort,
+	"SpectrePIN":     SpectrePIN,
+	"SpectreName":    SpectreName,
+	"SpectrePhrase":  SpectrePhrase,
+}
+
+// Stable returns the package level Generator with the given name, like "Digit",
+// "EFFLargeWordlist", "Emoji15" or "SpectreLong", and reports whether it exists.
+//
+// The output of every stable Generator, and of the encoding generators,
+// BIP39Mnemonic and ParseRegexp, is frozen for each Determinism version from the
+// release that introduced Stable: given the same input, it produces the same
+// passwords in that and every later release, and the embedded lists don't change,
+// as verified by ListChecksum. Any change that would alter the output requires a
+// new Determinism version.
+//
+// Releases from before Stable aren't covered.
+//
+// EmojiLatest isn't stable as it changes to the newest emoji list with each
+// release. Neither are generators built from a unicode.RangeTable in the unicode
+// package, like FromRangeTable(unicode.Greek) or the \p{Greek} class of a
+// regular expression, as those tables change with each version of Unicode.
+func Stable(name string) (Generator, bool) {
+	gen, ok := stableGenerators[name]
+	return gen, ok
+}
+
+// StableNames returns the names accepted by Stable in sorted order.
+func StableNames() []string {
+	names := make([]string, 0, len(stableGenerators))
+	for name := range stableGenerators {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ListChecksum returns the hex encoded SHA-256 hash of the list gen selects
+// from, with each entry followed by a newline, in the order the entries are
+// indexed. It can be used to confirm that two implementations use an identical
+// wordlist, emoji list or character set.
+//
+// gen must be a wordlist accepted by AnalyzeWordlist, an emoji list or a character
+// set like Digit or FromCharset. It returns an error for any other Generator.
+func ListChecksum(gen Generator) (string, error) {
+	var list []string
+	switch gen := gen.(type) {
+	case *asciiGenerator:
+		for i := range len(gen.s) {
+			list = append(list, gen.s[i:i+1])
+		}
+	case *runeGenerator:
+		for _, r := range (*utf8string.String)(gen).String() {
+			list = append(list, string(r))
+		}
+	case *unicodeGenerator:
+		for i := range gen.runes {
+			list = append(list, string(getRuneInTable(gen.tab, i)))
+		}
+	case wordlistGenerator:
+		list = gen.wordlist()
+	default:
+		return "", errors.New("passit: generator is not a list")
+	}
+
+	h := sha256.New()
+	for _, entry := range list {
+		h.Write([]byte(entry))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
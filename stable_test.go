@@ -0,0 +1,406 @@
+package passit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableComplete(t *testing.T) {
+	// Every exported package level Generator and SpectreTemplate must be
+	// stable, so that none are accidentally left without golden vectors.
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		require.NoError(t, err)
+
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || (gd.Tok != token.VAR && gd.Tok != token.CONST) {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				typ, ok := vs.Type.(*ast.Ident)
+				if !ok || (typ.Name != "Generator" && typ.Name != "SpectreTemplate") {
+					continue
+				}
+
+				for _, name := range vs.Names {
+					if name.IsExported() {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+
+	assert.Equal(t, names, StableNames())
+
+	for _, name := range StableNames() {
+		gen, ok := Stable(name)
+		assert.Truef(t, ok, "Stable(%q)", name)
+		assert.NotNilf(t, gen, "Stable(%q)", name)
+	}
+
+	_, ok := Stable("EmojiLatest")
+	assert.False(t, ok, `Stable("EmojiLatest")`)
+}
+
+// stableInputs are the inputs used for the golden vectors of each Determinism
+// version.
+var stableInputs = map[Determinism]func() io.Reader{
+	DeterminismBytes1:  newTestRand,
+	DeterminismBits1:   func() io.Reader { return NewBitReader(newTestRand()) },
+	DeterminismSource1: func() io.Reader { return NewChaCha8Source([32]byte{}) },
+}
+
+// stableParameterized are the stable generators that are created by a function.
+//
+// They overlap with the specs of go.tmthrgd.dev/passit/testvectors on purpose.
+// Those only cover passit/bytes/1 and passit/bits/1, as passit/source/1 can't be
+// exported, while these goldens pin every Determinism, including
+// DeterminismSource1. The testvectors package imports passit, so its specs can't
+// be shared here. Add a generator to both when it becomes stable.
+func stableParameterized(t *testing.T) map[string]Generator {
+	regexp := func(pattern string) Generator {
+		gen, err := ParseRegexp(pattern, syntax.Perl)
+		require.NoError(t, err)
+		return gen
+	}
+
+	return map[string]Generator{
+		"HexLower(8)":        HexLower(8),
+		"HexUpper(8)":        HexUpper(8),
+		"Base32(10)":         Base32(10),
+		"Base32Hex(10)":      Base32Hex(10),
+		"Base64(12)":         Base64(12),
+		"Base64URL(12)":      Base64URL(12),
+		"Ascii85(8)":         Ascii85(8),
+		"BIP39Mnemonic(12)":  BIP39Mnemonic(12),
+		"FromCharset(αβγδε)": FromCharset("αβγδε"),
+
+		`ParseRegexp([a-z]{4}-\d{4})`:              regexp(`[a-z]{4}-\d{4}`),
+		`ParseRegexp((foo|bar)[[:upper:]]{2,5}x?)`: regexp(`(foo|bar)[[:upper:]]{2,5}x?`),
+		`ParseRegexp(.{6}[^a-z]+(?i:pass))`:        regexp(`.{6}[^a-z]+(?i:pass)`),
+	}
+}
+
+// stableGolden are the golden vectors of each Determinism version. Each is the
+// output of Repeat(gen, " ", 3) for the input in stableInputs.
+//
+// These must never change. If a change to passit alters any of these outputs, it
+// must instead be made under a new Determinism version with its own vectors.
+var stableGolden = map[Determinism]map[string]string{
+	DeterminismBytes1: {
+		"ASCIIGraphic":          ") l M",
+		"ASCIINoLetters":        "3 8 \\",
+		"ASCIINoLettersNumbers": "' * ,",
+		"Ascii85(8)":            "B'Dt<mtrYX LeRYJattV$ =Q98(qL1,,",
+		"BIP39English":          "coast maximum fuel",
+		"BIP39French":           "cercle kayak évasion",
+		"BIP39FrenchASCII":      "cercle kayak evasion",
+		"BIP39Italian":          "citrico neve giallo",
+		"BIP39Mnemonic(12)":     "group engage vivid tenant people build cancel palm flush faculty approve frequent flight blood crisp visual toast correct supreme together fish cheese spend remove adjust egg receive scrap health siren smile blue ride mirror sauce valley",
+		"BIP39Spanish":          "catre margen freír",
+		"BIP39SpanishASCII":     "catre margen freir",
+		"Base32(10)":            "M3UUXVHPRIWDXCCM 7JM4UNBLFZMOF7GO 7J7DAYJWP4OVPJHH",
+		"Base32Hex(10)":         "CRKKNL7FH8M3N22C V9CSKD1B5PCE5V6E V9V30O9MFSELF977",
+		"Base64(12)":            "ZulL1O+KLDuITPpZ yjQrLlji/M76fjBh Nn8dV6TnRVoDiNrO",
+		"Base64URL(12)":         "ZulL1O-KLDuITPpZ yjQrLlji_M76fjBh Nn8dV6TnRVoDiNrO",
+		"Digit":                 "2 3 5",
+		"EFFLargeWordlist":      "reprint wool pantry",
+		"EFFShortWordlist1":     "bush vapor issue",
+		"EFFShortWordlist2":     "barracuda vegetable idly",
+		"Emoji13":               "💙 🏂🏽 🧑🏽\u200d🦱",
+		"Emoji15":               "➡️ 🦸🏼\u200d♂️ 👩🏾\u200d🦳",
+		"Empty":                 "  ",
+		"FromCharset(αβγδε)":    "γ δ α",
+		"HexLower(8)":           "66e94bd4ef8a2c3b 884cfa59ca342b2e 58e2fccefa7e3061",
+		"HexUpper(8)":           "66E94BD4EF8A2C3B 884CFA59CA342B2E 58E2FCCEFA7E3061",
+		"Hyphen":                "- - -",
+		"LatinLower":            "y z x",
+		"LatinLowerDigit":       "4 r d",
+		"LatinMixed":            "Y x I",
+		"LatinMixedDigit":       "O V n",
+		"LatinUpper":            "Y Z X",
+		"LatinUpperDigit":       "4 R D",
+		"LexiconAdjective":      "prickly chubby hungry",
+		"LexiconAdverb":         "genuinely enormously valiantly",
+		"LexiconNoun":           "engine bathtub pond",
+		"LexiconVerb":           "gargled doodled swerved",
+		"OrchardStreetAlpha":    "bees told hymn",
+		"OrchardStreetLong":     "agreed stopping brilliant",
+		"OrchardStreetMedium":   "pavilion extinct stadium",
+		"OrchardStreetQWERTY":   "bids trio hurry",
+		"OrchardStreetAlphaV2":  "bowl undo jeep",
+		"OrchardStreetLongV2":   "agreement stopping brilliantly",
+		"OrchardStreetMediumV2": "easier pays extracted",
+		"OrchardStreetQWERTYV2": "bus vast jets",
+		"ParseRegexp((foo|bar)[[:upper:]]{2,5}x?)": "fooXEI barYL fooUKSYW",
+		"ParseRegexp(.{6}[^a-z]+(?i:pass))":        "'kKL[I4`TKN3~YP<VZ=pAss z#I!wdHXOpASS Vkliky~*!WPASs",
+		"ParseRegexp([a-z]{4}-\\d{4})":             "yzxe-9849 gylu-2368 syww-7479",
+		"STS10Wordlist":                            "winner vertigo spurs",
+		"Space":                                    "     ",
+		"SpectreBasic":                             "Rfr9cSj2 qt86yQw7 RYg4KkO6",
+		"SpectreLong":                              "Dadl8(WeraHinc GewyBoru7=Fubu Fele6#VeqyHalo",
+		"SpectreMaximum":                           "R2.%r7#UK60qtJ!2wT23 gN*LO#!SkMImynnfwa0? RKg$U^xcIKdeqUc$kX8?",
+		"SpectreMedium":                            "Dad9~Dun Yur2;Gov Wab8&Qil",
+		"SpectreName":                              "xoqduquwe rahricege wabjiqili",
+		"SpectrePIN":                               "2352 9849 6692",
+		"SpectrePhrase":                            "dadl quw neyhino gov wabj ruc liwbujo now sozq yuw lotvude nah",
+		"SpectreShort":                             "Xoq2 Lod9 Ney9",
+	},
+	DeterminismBits1: {
+		"ASCIIGraphic":          ": B 5",
+		"ASCIINoLetters":        ", 6 '",
+		"ASCIINoLettersNumbers": "* , _",
+		"Ascii85(8)":            "B'Dt<mtrYX LeRYJattV$ =Q98(qL1,,",
+		"BIP39English":          "city ritual vanish",
+		"BIP39French":           "capsule pizza tuile",
+		"BIP39FrenchASCII":      "capsule pizza tuile",
+		"BIP39Italian":          "cena rivolto unitario",
+		"BIP39Mnemonic(12)":     "group engage vivid tenant people build cancel palm flush faculty approve frequent flight blood crisp visual toast correct supreme together fish cheese spend remove adjust egg receive scrap health siren smile blue ride mirror sauce valley",
+		"BIP39Spanish":          "canica preso turbina",
+		"BIP39SpanishASCII":     "canica preso turbina",
+		"Base32(10)":            "M3UUXVHPRIWDXCCM 7JM4UNBLFZMOF7GO 7J7DAYJWP4OVPJHH",
+		"Base32Hex(10)":         "CRKKNL7FH8M3N22C V9CSKD1B5PCE5V6E V9V30O9MFSELF977",
+		"Base64(12)":            "ZulL1O+KLDuITPpZ yjQrLlji/M76fjBh Nn8dV6TnRVoDiNrO",
+		"Base64URL(12)":         "ZulL1O-KLDuITPpZ yjQrLlji_M76fjBh Nn8dV6TnRVoDiNrO",
+		"Digit":                 "5 4 3",
+		"EFFLargeWordlist":      "flanked blinker stalemate",
+		"EFFShortWordlist1":     "ahead canon dodge",
+		"EFFShortWordlist2":     "academy boiler durable",
+		"Emoji13":               "💂🏻\u200d♂️ 🈺 🍎",
+		"Emoji15":               "🥭 🧦 💂🏾\u200d♂️",
+		"Empty":                 "  ",
+		"FromCharset(αβγδε)":    "α ε δ",
+		"HexLower(8)":           "66e94bd4ef8a2c3b 884cfa59ca342b2e 58e2fccefa7e3061",
+		"HexUpper(8)":           "66E94BD4EF8A2C3B 884CFA59CA342B2E 58E2FCCEFA7E3061",
+		"Hyphen":                "- - -",
+		"LatinLower":            "h b t",
+		"LatinLowerDigit":       "3 l q",
+		"LatinMixed":            "H B u",
+		"LatinMixedDigit":       "5 5 w",
+		"LatinUpper":            "H B T",
+		"LatinUpperDigit":       "3 L Q",
+		"LexiconAdjective":      "odd grand hungry",
+		"LexiconAdverb":         "tiredly enormously solemnly",
+		"LexiconNoun":           "drum pepper pond",
+		"LexiconVerb":           "stood doodled skidded",
+		"OrchardStreetAlpha":    "zip food race",
+		"OrchardStreetLong":     "province bulk joints",
+		"OrchardStreetMedium":   "consisting stained tear",
+		"OrchardStreetQWERTY":   "zen gave reared",
+		"OrchardStreetAlphaV2":  "added bust door",
+		"OrchardStreetLongV2":   "recessive container shortcuts",
+		"OrchardStreetMediumV2": "dumb plausible valve",
+		"OrchardStreetQWERTYV2": "agree call elm",
+		"ParseRegexp((foo|bar)[[:upper:]]{2,5}x?)": "barRNx barDNx fooWOI",
+		"ParseRegexp(.{6}[^a-z]+(?i:pass))":        ">63G9)%JQVC NQS^(P PASs r0*fl8^pasS Z,eP?DGPAsS",
+		"ParseRegexp([a-z]{4}-\\d{4})":             "hbtg-9150 rgpx-6068 igol-1056",
+		"STS10Wordlist":                            "harrow rodeo handling",
+		"Space":                                    "     ",
+		"SpectreBasic":                             "sWN07JBX vim6HIj9 fNU93ewL",
+		"SpectreLong":                              "MariPuviPasr9$ WixbGoqi6~Rasa WeruGosoMocu4#",
+		"SpectreMaximum":                           "Jq&HDyXO#nUVuffrWB8] D6/8!6SewKBeb*)pNiYY s5?JfQR4ZgoVOwbpdEpE",
+		"SpectreMedium":                            "HilZuk1; Jad8;Won QijPuf0^",
+		"SpectreName":                              "pugjipuvi pasruxifi ceftekayu",
+		"SpectrePIN":                               "5430 6571 7092",
+		"SpectrePhrase":                            "fo wuvji ziy lehoqli nofx xad zempice rep hil toppibube mecu",
+		"SpectreShort":                             "Pug1 Goj3 Tiq4",
+	},
+	DeterminismSource1: {
+		"ASCIIGraphic":          "` H ,",
+		"ASCIINoLetters":        "= 2 &",
+		"ASCIINoLettersNumbers": "^ _ `",
+		"Ascii85(8)":            "fnY%%D)R9Q )K.p5`[AfB )uC_PHpOGI",
+		"BIP39English":          "will today gloom",
+		"BIP39French":           "vinaigre sublime fatal",
+		"BIP39FrenchASCII":      "vinaigre sublime fatal",
+		"BIP39Italian":          "virulento tattico ibernato",
+		"BIP39Mnemonic(12)":     "sunset desk receive surprise hamster figure have trial paddle couple vault strike brief suggest trip weasel borrow monster museum forum heart egg celery trouble essay increase orbit brick stuff keen critic myself page price sugar roast",
+		"BIP39Spanish":          "violín técnica germen",
+		"BIP39SpanishASCII":     "violin tecnica germen",
+		"Base32(10)":            "3GDX5TTNG2FKYGTP IGPMMJ6HNMN7WH5D PRA2CHVENLOWUSGY",
+		"Base32Hex(10)":         "R63NTJJD6Q5AO6JF 86FCC9U7DCDVM7T3 FH0Q27L4DBEMKI6O",
+		"Base64(12)":            "2Yd+zm02iqwab0Ge xifHaxv7H6N8QaEe pGrdakjYlHRNLlZv",
+		"Base64URL(12)":         "2Yd-zm02iqwab0Ge xifHaxv7H6N8QaEe pGrdakjYlHRNLlZv",
+		"Digit":                 "6 4 1",
+		"EFFLargeWordlist":      "reliable humid cavalier",
+		"EFFShortWordlist1":     "rigor heat case",
+		"EFFShortWordlist2":     "pelican headband boxlike",
+		"Emoji13":               "🧑\u200d🍼 🇧🇯 👫",
+		"Emoji15":               "🧖\u200d♂️ 🇯🇵 💖",
+		"Empty":                 "  ",
+		"FromCharset(αβγδε)":    "δ γ α",
+		"HexLower(8)":           "d9877ece6d368aac 1a6f419ec627c76b 1bfb1fa37c41a11e",
+		"HexUpper(8)":           "D9877ECE6D368AAC 1A6F419EC627C76B 1BFB1FA37C41A11E",
+		"Hyphen":                "- - -",
+		"LatinLower":            "r k d",
+		"LatinLowerDigit":       "y p e",
+		"LatinMixed":            "J v g",
+		"LatinMixedDigit":       "P A h",
+		"LatinUpper":            "R K D",
+		"LatinUpperDigit":       "Y P E",
+		"LexiconAdjective":      "tidy lofty lonely",
+		"LexiconAdverb":         "stiffly briskly broadly",
+		"LexiconNoun":           "volcano rake rampart",
+		"LexiconVerb":           "slipped bounced bowed",
+		"OrchardStreetAlpha":    "posts hunt cabin",
+		"OrchardStreetLong":     "positivism guarding bugs",
+		"OrchardStreetMedium":   "prejudice halted butterfly",
+		"OrchardStreetQWERTY":   "pupil hung cares",
+		"OrchardStreetAlphaV2":  "post hung cabin",
+		"OrchardStreetLongV2":   "positively guardians buffet",
+		"OrchardStreetMediumV2": "denying inputs spouse",
+		"OrchardStreetQWERTYV2": "punt hum cares",
+		"ParseRegexp((foo|bar)[[:upper:]]{2,5}x?)": "barDLYJx fooLRx fooKK",
+		"ParseRegexp(.{6}[^a-z]+(?i:pass))":        "`G+KzB]$>MHF!=;/RIpass B.OeYpMPaSS =--'~I=55I80IKpass",
+		"ParseRegexp([a-z]{4}-\\d{4})":             "rkdl-9328 blrp-5043 ftpb-3533",
+		"STS10Wordlist":                            "punctual hiatus busted",
+		"Space":                                    "     ",
+		"SpectreBasic":                             "PeR93FtO fZ50PNC7 OK53LiV7",
+		"SpectreLong":                              "LamyKexcMoqi0- GoqaKilkFito8= BornJafc9-Dije",
+		"SpectreMaximum":                           "Poc&VL@abvpmEZXJ1p0_ NVBh0q9HvArsfQBBa)4% J3#TJqsNEdmR3OYjMG3O",
+		"SpectreMedium":                            "LamYeh8& RipBil2^ CenLef5^",
+		"SpectreName":                              "sidmukexa moqpalego qaknekano",
+		"SpectrePIN":                               "6414 9328 0465",
+		"SpectrePhrase":                            "la mukhu cir qibilgo cen lefnoqugo bori facz mal jeqkeqo ham",
+		"SpectreShort":                             "Sid4 Yeh8 Cir5",
+	},
+}
+
+func TestStableGolden(t *testing.T) {
+	gens := stableParameterized(t)
+	for _, name := range StableNames() {
+		gens[name], _ = Stable(name)
+	}
+
+	for d, golden := range stableGolden {
+		for name, gen := range gens {
+			pass, err := Repeat(gen, " ", 3).Password(stableInputs[d]())
+			if !assert.NoErrorf(t, err, "%s: %s", d, name) {
+				continue
+			}
+
+			expect, ok := golden[name]
+			if assert.Truef(t, ok, "%s: %s: missing golden vector %q", d, name, pass) {
+				assert.Equalf(t, expect, pass, "%s: %s", d, name)
+			}
+		}
+
+		for name := range golden {
+			assert.Containsf(t, gens, name, "%s: golden vector for unknown generator", d)
+		}
+	}
+}
+
+// stableChecksums are the checksums of every stable list.
+var stableChecksums = map[string]string{
+	"ASCIIGraphic":          "d39a8797c560b434fe58e910a31c4e5454a6626602b7114a41509fa12792c1a2",
+	"ASCIINoLetters":        "7b706510d1df16ac4c7fd37c91ff106b6a9c3f20e5dd7b1c182413c1988ebf29",
+	"ASCIINoLettersNumbers": "a6bbec3a7664ad3698c79a9f85d06b6e1e5bb7f59a84bebc75f5c5ed5c80e0cb",
+	"BIP39English":          "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda",
+	"BIP39French":           "431c1d074225d2b7e82db857d7c3ea58051df546e7c8b74c1f6dcab36351fd56",
+	"BIP39FrenchASCII":      "cf8db448ad5ca0b68c3c8f88b51458f20362a5939fef236fac64a303f1485e87",
+	"BIP39Italian":          "d392c49fdb700a24cd1fceb237c1f65dcc128f6b34a8aacb58b59384b5c648c2",
+	"BIP39Spanish":          "0c639b0d58b6e56c18dcf418017ff341418a129e45fbf303361e8569edb02efe",
+	"BIP39SpanishASCII":     "825b1c91b0084d16640c9ffbb643acfca15971225e3b8d2e2109fac949f34543",
+	"Digit":                 "7427877c40fb0361401248f9c96abe6117396bc6ab16811b5b1706274c02443e",
+	"EFFLargeWordlist":      "6d557f0693958fb5e650b68b5bee585eb82cf4da32965505c789e924743bc522",
+	"EFFShortWordlist1":     "36ecca49e4fa20ca84b176c32f2e9c82f98f446585190e75f9879a95c08247bf",
+	"EFFShortWordlist2":     "7aa57a4d3ecf6581729992bad9575bacdebf7c28378af2aec6a50f11aec326f5",
+	"Emoji13":               "24da0484d6c87d3687268546b7fb7389b4072e4c89e10389ca99983f17368eb3",
+	"Emoji15":               "7ef71f3aa17adc7348544d74a295727a4616a0f13ffa9ce278c31f7e00b9242d",
+	"LatinLower":            "e2675e968ab5c9e5b16c816e41f4a294e3880ef8122ed5207218658c64716ede",
+	"LatinLowerDigit":       "880d477416188453c59b9c457fafb478611bdc31fdc8363473a33231b8e4abc3",
+	"LatinMixed":            "1c617e64ed74530bfba1d05a50b99baa489abcdc77a9289f99ba0ead5fd7a673",
+	"LatinMixedDigit":       "f6ab02739b2ab4db8e0f2b472b3a06d1798ee9cf7e9830a696f46e04e630ae43",
+	"LatinUpper":            "e4e76ed00d9b1701fb1a0eb648450ce9037d94893a880ac9d02765dbb531dc9b",
+	"LatinUpperDigit":       "32569fd137b6b9fdd13a48c731bf640ff91c6268377098fb6eb265bc8ddd77d6",
+	"LexiconAdjective":      "64f860ef66ca4fc7a2d576d0ada5ce7eea5a67c8ba53b89dd833ca57c027e2c2",
+	"LexiconAdverb":         "51ee8b8be0c7d99f79832b3bb336d15a393786200d7a4cb776293da0066ed8c9",
+	"LexiconNoun":           "a6500936143c4288531f3b1fbf6995c5f6651e589c4b952769a4cea5a7c4997e",
+	"LexiconVerb":           "e9718c458ac5eba770684e4ac88b161ec4c5a6cc2b604e1087de494675244bf6",
+	"OrchardStreetAlpha":    "2cfe6c41603db7bff26103c0167dc1fa329b81965e4423b96771087fcf826049",
+	"OrchardStreetLong":     "8d76be59678e3791f0e2977269f8a355d68dcc9f14973689f4adf9070ab632d6",
+	"OrchardStreetMedium":   "ad50d15011b441e6c71b1ad562bc7be312f4a9f6d66ab3d5f3100958e8280cde",
+	"OrchardStreetQWERTY":   "4165636743f33cdc0223329e9212265461e4dab4afd13ef17ab7a7094f51d970",
+	"OrchardStreetAlphaV2":  "3123ff0eae590919fbf624469ef474017f3063626a09aec2c0b36323c6cd5a67",
+	"OrchardStreetLongV2":   "6e41e19b726dc1c2d30435b9c8afc7eef6074ac4515b2b66ecefcf0862cc290f",
+	"OrchardStreetMediumV2": "12600f013364c7b11eeadb8eb99dce37109ffd6250d6522def86267c831b61af",
+	"OrchardStreetQWERTYV2": "9abda373e09ecea5a6c143739c24dcabff4b89b58ffc973d528c37b4e3dcddc0",
+	"STS10Wordlist":         "fe8d082cad1bd1ffc6266511d97c8e7caa59dcab5f20f3c515e32a4688756d1f",
+}
+
+func TestListChecksum(t *testing.T) {
+	for _, name := range StableNames() {
+		gen, _ := Stable(name)
+		sum, err := ListChecksum(gen)
+		if err != nil {
+			assert.EqualErrorf(t, err, "passit: generator is not a list", "ListChecksum(%s)", name)
+			continue
+		}
+
+		expect, ok := stableChecksums[name]
+		if assert.Truef(t, ok, "ListChecksum(%s): missing checksum %q", name, sum) {
+			assert.Equalf(t, expect, sum, "ListChecksum(%s)", name)
+		}
+	}
+
+	// The checksum is of each entry followed by a newline.
+	sum, err := ListChecksum(FromSlice("a", "b"))
+	require.NoError(t, err)
+	assert.Equal(t, "911169ddaaf146aff539f58c26c489af3b892dff0fe283c1c264c65ae5aa59a2", sum)
+
+	sum, err = ListChecksum(FromCharset("αβ"))
+	require.NoError(t, err)
+	assert.Equal(t, "82c4e8109513a40d30a336f0084ba7445b66c0bd203e746b1107118fa4bbda7e", sum)
+
+	sum, err = ListChecksum(FromRangeTable(&unicode.RangeTable{
+		R16: []unicode.Range16{{Lo: 'a', Hi: 'b', Stride: 1}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "911169ddaaf146aff539f58c26c489af3b892dff0fe283c1c264c65ae5aa59a2", sum)
+
+	_, err = ListChecksum(HexLower(4))
+	assert.EqualError(t, err, "passit: generator is not a list")
+}
+
+func TestStableOrchardLegacy(t *testing.T) {
+	// The Orchard Street generators keep the empty word that the first
+	// releases split off the end of each list, while the V2 generators don't.
+	for _, tc := range []struct {
+		name       string
+		legacy, v2 Generator
+	}{
+		{"OrchardStreetMedium", OrchardStreetMedium, OrchardStreetMediumV2},
+		{"OrchardStreetLong", OrchardStreetLong, OrchardStreetLongV2},
+		{"OrchardStreetAlpha", OrchardStreetAlpha, OrchardStreetAlphaV2},
+		{"OrchardStreetQWERTY", OrchardStreetQWERTY, OrchardStreetQWERTYV2},
+	} {
+		words, err := FilterWordlist(tc.v2, func(string) bool { return true })
+		require.NoError(t, err)
+		assert.Equalf(t, append(words.Words(), ""), tc.legacy.(wordlistGenerator).wordlist(), "%s", tc.name)
+	}
+}
@@ -0,0 +1,59 @@
+package testvectors
+
+import "go.tmthrgd.dev/passit"
+
+// namedSpec is a Spec with the name of its vectors.
+type namedSpec struct {
+	Name string
+	Spec *Spec
+}
+
+// specs are the generators that Generate creates vectors for. Vectors are only
+// ever added, never changed or removed.
+//
+// The parameterized specs overlap with stableParameterized in passit's
+// stable_test.go, which also pins passit/source/1. Keep the two in step.
+var specs = func() []namedSpec {
+	var specs []namedSpec
+	for _, name := range passit.StableNames() {
+		specs = append(specs, namedSpec{name, &Spec{Gen: name}})
+	}
+
+	eff := &Spec{Gen: "EFFLargeWordlist"}
+	return append(specs, []namedSpec{
+		{"HexLower(8)", &Spec{Gen: "HexLower", Count: 8}},
+		{"HexUpper(8)", &Spec{Gen: "HexUpper", Count: 8}},
+		{"Base32(10)", &Spec{Gen: "Base32", Count: 10}},
+		{"Base32Hex(10)", &Spec{Gen: "Base32Hex", Count: 10}},
+		{"Base64(12)", &Spec{Gen: "Base64", Count: 12}},
+		{"Base64URL(12)", &Spec{Gen: "Base64URL", Count: 12}},
+		{"Ascii85(8)", &Spec{Gen: "Ascii85", Count: 8}},
+		{"BIP39Mnemonic(12)", &Spec{Gen: "BIP39Mnemonic", Count: 12}},
+		{"BIP39Mnemonic(24)", &Spec{Gen: "BIP39Mnemonic", Count: 24}},
+		{"FromCharset", &Spec{Gen: "FromCharset", Charset: "αβγδε"}},
+		{"FromSlice", &Spec{Gen: "FromSlice", List: []string{"red", "green", "blue"}}},
+		{"String", &Spec{Gen: "String", Value: "fixed"}},
+		{"Regexp/literal", &Spec{Gen: "Regexp", Pattern: `[a-z]{4}-\d{4}`}},
+		{"Regexp/alternate", &Spec{Gen: "Regexp", Pattern: `(foo|bar)[[:upper:]]{2,5}x?`}},
+		{"Regexp/any", &Spec{Gen: "Regexp", Pattern: `.{6}[^a-z]+(?i:pass)`}},
+		{"Regexp/unbounded", &Spec{Gen: "Regexp", Pattern: `a*b+c?`}},
+		{"Sentence", &Spec{Gen: "Sentence", Template: passit.DefaultSentenceTemplate}},
+		{"Join", &Spec{Gen: "Join", Sep: "-", Gens: []*Spec{
+			{Gen: "Digit"}, eff, {Gen: "HexLower", Count: 2},
+		}}},
+		{"Alternate", &Spec{Gen: "Alternate", Gens: []*Spec{
+			{Gen: "SpectreLong"}, eff, {Gen: "Emoji15"},
+		}}},
+		{"Repeat", &Spec{Gen: "Repeat", Of: eff, Sep: "-", Count: 6}},
+		{"Repeat/digits", &Spec{Gen: "Repeat", Of: &Spec{Gen: "Digit"}, Count: 12}},
+		{"RepeatGen", &Spec{Gen: "RepeatGen", Of: &Spec{Gen: "EFFShortWordlist1"}, SepGen: &Spec{Gen: "Digit"}, Count: 4}},
+		{"RandomRepeat", &Spec{Gen: "RandomRepeat", Of: &Spec{Gen: "LatinMixed"}, Min: 8, Max: 16}},
+		{"RepeatLength", &Spec{Gen: "RepeatLength", Of: eff, Sep: "-", Count: 4, Min: 20, Max: 24}},
+		{"LowerCase", &Spec{Gen: "LowerCase", Of: &Spec{Gen: "LatinMixedDigit"}}},
+		{"UpperCase", &Spec{Gen: "UpperCase", Of: eff}},
+		{"ConstrainLength/bytes", &Spec{Gen: "ConstrainLength", Measure: "bytes", Min: 8, Max: 16,
+			Of: &Spec{Gen: "RandomRepeat", Of: &Spec{Gen: "Emoji15"}, Min: 2, Max: 6}}},
+		{"ConstrainLength/utf16", &Spec{Gen: "ConstrainLength", Measure: "utf16", Min: 4, Max: 8,
+			Of: &Spec{Gen: "RandomRepeat", Of: &Spec{Gen: "Emoji15"}, Min: 2, Max: 6}}},
+	}...)
+}()
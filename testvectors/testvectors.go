@@ -0,0 +1,412 @@
+// Package testvectors defines a JSON test vector format for passit, so that other
+// implementations can prove that they generate the same deterministic passwords.
+//
+// A file contains a list of vectors. Each vector describes a generator with a
+// Spec, the exact input bytes it reads, how those bytes are read and the
+// passwords that are generated, one after another, from that input. An
+// implementation is compatible if, for every vector, it generates the same
+// passwords while reading exactly the given input.
+//
+// The vectors published with passit are in vectors.json in this directory. They
+// are regenerated with:
+//
+//	go generate go.tmthrgd.dev/passit/testvectors
+package testvectors
+
+//go:generate go run ../cmd/testvectors -o vectors.json
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"slices"
+
+	"go.tmthrgd.dev/passit"
+)
+
+// Version is the version of the file format.
+const Version = 1
+
+// File is a test vector file.
+type File struct {
+	// Version is the version of the file format. It is always Version.
+	Version int `json:"version"`
+
+	// Vectors are the test vectors.
+	Vectors []Vector `json:"vectors"`
+
+	// Lists maps the name of each stable generator that selects from a list, as
+	// returned by passit.Stable, to the passit.ListChecksum of that list. An
+	// implementation whose lists match these checksums uses identical wordlists,
+	// emoji lists and character sets.
+	Lists map[string]string `json:"lists"`
+}
+
+// Vector is a single test vector.
+//
+// Vectors only use passit/bytes/1 and passit/bits/1. passit/source/1 is
+// deliberately not supported: its input is the sequence of values returned by a
+// passit.Source rather than bytes, so it can't be expressed as Input, and
+// reproducing it from a seed would test the math/rand/v2 algorithms behind
+// passit.RandSource rather than passit.
+type Vector struct {
+	// Name uniquely identifies the vector within the file.
+	Name string `json:"name"`
+
+	// Determinism is how the input is read, either passit/bytes/1 to read the
+	// input directly or passit/bits/1 to read it through a passit.BitReader.
+	Determinism passit.Determinism `json:"determinism"`
+
+	// Generator describes the generator.
+	Generator *Spec `json:"generator"`
+
+	// Input is the hex encoded input. Generating all of Passwords reads every
+	// byte of it.
+	Input string `json:"input"`
+
+	// Passwords are the passwords generated, in order, from Input.
+	Passwords []string `json:"passwords"`
+}
+
+// Spec describes a Generator. Gen is the name of a stable generator returned by
+// passit.Stable, like "EFFLargeWordlist", or of a function in passit that creates
+// a generator, like "Repeat". The other fields are the arguments to that function
+// and are omitted when unused.
+//
+// The supported functions and the fields they use are:
+//
+//	HexLower, HexUpper, Base32, Base32Hex, Base64, Base64URL, Ascii85: count
+//	BIP39Mnemonic: count (the number of words)
+//	FromCharset: charset
+//	FromSlice: list
+//	String: value
+//	Regexp: pattern (parsed by passit.ParseRegexp with syntax.Perl)
+//	Sentence: template (with the default slots)
+//	Join: sep, gens
+//	Alternate: gens
+//	Repeat: of, sep, count
+//	RepeatGen: of, sepGen, count
+//	RandomRepeat: of, sep, min, max
+//	RepeatLength: of, sep, count, min, max
+//	LowerCase, UpperCase: of
+//	ConstrainLength: of, measure, min, max
+//
+// measure is one of "bytes", "runes", "graphemes" or "utf16".
+type Spec struct {
+	Gen      string   `json:"gen"`
+	Of       *Spec    `json:"of,omitempty"`
+	Gens     []*Spec  `json:"gens,omitempty"`
+	Sep      string   `json:"sep,omitempty"`
+	SepGen   *Spec    `json:"sepGen,omitempty"`
+	Count    int      `json:"count,omitempty"`
+	Min      int      `json:"min,omitempty"`
+	Max      int      `json:"max,omitempty"`
+	Charset  string   `json:"charset,omitempty"`
+	List     []string `json:"list,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Template string   `json:"template,omitempty"`
+	Measure  string   `json:"measure,omitempty"`
+}
+
+var measures = map[string]passit.LengthMeasure{
+	"bytes":     passit.LengthBytes,
+	"runes":     passit.LengthRunes,
+	"graphemes": passit.LengthGraphemes,
+	"utf16":     passit.LengthUTF16,
+}
+
+// Generator returns the Generator described by s. It returns an error if s is
+// invalid.
+func (s *Spec) Generator() (gen passit.Generator, err error) {
+	// The passit functions panic on invalid arguments.
+	defer func() {
+		if v := recover(); v != nil {
+			gen, err = nil, fmt.Errorf("testvectors: invalid %s: %v", s.Gen, v)
+		}
+	}()
+
+	if gen, ok := passit.Stable(s.Gen); ok {
+		return gen, nil
+	}
+
+	switch s.Gen {
+	case "HexLower":
+		return passit.HexLower(s.Count), nil
+	case "HexUpper":
+		return passit.HexUpper(s.Count), nil
+	case "Base32":
+		return passit.Base32(s.Count), nil
+	case "Base32Hex":
+		return passit.Base32Hex(s.Count), nil
+	case "Base64":
+		return passit.Base64(s.Count), nil
+	case "Base64URL":
+		return passit.Base64URL(s.Count), nil
+	case "Ascii85":
+		return passit.Ascii85(s.Count), nil
+	case "BIP39Mnemonic":
+		return passit.BIP39Mnemonic(s.Count), nil
+	case "FromCharset":
+		return passit.FromCharset(s.Charset), nil
+	case "FromSlice":
+		return passit.FromSlice(s.List...), nil
+	case "String":
+		return passit.String(s.Value), nil
+	case "Regexp":
+		return passit.ParseRegexp(s.Pattern, syntax.Perl)
+	case "Sentence":
+		return passit.NewSentence(s.Template, nil)
+	case "Join", "Alternate":
+		gens, err := specGenerators(s.Gens)
+		if err != nil {
+			return nil, err
+		}
+		if s.Gen == "Join" {
+			return passit.Join(s.Sep, gens...), nil
+		}
+		return passit.Alternate(gens...), nil
+	case "Repeat", "RepeatGen", "RandomRepeat", "RepeatLength",
+		"LowerCase", "UpperCase", "ConstrainLength":
+		return s.wrapperGenerator()
+	default:
+		return nil, fmt.Errorf("testvectors: unknown generator %q", s.Gen)
+	}
+}
+
+// wrapperGenerator returns the Generator for a Spec that wraps the Generator
+// described by Of.
+func (s *Spec) wrapperGenerator() (passit.Generator, error) {
+	of, err := s.Of.generator(s.Gen, "of")
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.Gen {
+	case "Repeat":
+		return passit.Repeat(of, s.Sep, s.Count), nil
+	case "RepeatGen":
+		sep, err := s.SepGen.generator(s.Gen, "sepGen")
+		if err != nil {
+			return nil, err
+		}
+		return passit.RepeatGen(of, sep, s.Count), nil
+	case "RandomRepeat":
+		return passit.RandomRepeat(of, s.Sep, s.Min, s.Max), nil
+	case "RepeatLength":
+		return passit.RepeatLength(of, s.Sep, s.Count, s.Min, s.Max)
+	case "LowerCase":
+		return passit.LowerCase(of), nil
+	case "UpperCase":
+		return passit.UpperCase(of), nil
+	case "ConstrainLength":
+		m, ok := measures[s.Measure]
+		if !ok {
+			return nil, fmt.Errorf("testvectors: unknown length measure %q", s.Measure)
+		}
+		return passit.ConstrainLength(of, m, s.Min, s.Max), nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// generator is like Generator, but returns an error if s is nil.
+func (s *Spec) generator(parent, field string) (passit.Generator, error) {
+	if s == nil {
+		return nil, fmt.Errorf("testvectors: %s is missing %s", parent, field)
+	}
+	return s.Generator()
+}
+
+func specGenerators(specs []*Spec) ([]passit.Generator, error) {
+	gens := make([]passit.Generator, len(specs))
+	for i, spec := range specs {
+		gen, err := spec.generator("generator", "spec")
+		if err != nil {
+			return nil, err
+		}
+		gens[i] = gen
+	}
+	return gens, nil
+}
+
+// newReader returns the reader for the input following d.
+func newReader(d passit.Determinism, input io.Reader) (io.Reader, error) {
+	switch d {
+	case passit.DeterminismBytes1:
+		return input, nil
+	case passit.DeterminismBits1:
+		return passit.NewBitReader(input), nil
+	default:
+		return nil, fmt.Errorf("testvectors: unsupported determinism %q", d)
+	}
+}
+
+// Check reports whether the passit generator described by v generates the
+// expected passwords while reading exactly the given input.
+func (v *Vector) Check() error {
+	input, err := hex.DecodeString(v.Input)
+	if err != nil {
+		return fmt.Errorf("testvectors: %s: invalid input: %w", v.Name, err)
+	}
+
+	gen, err := v.Generator.generator(v.Name, "generator")
+	if err != nil {
+		return err
+	}
+
+	br := bytes.NewReader(input)
+	r, err := newReader(v.Determinism, br)
+	if err != nil {
+		return err
+	}
+
+	for i, expect := range v.Passwords {
+		pass, err := gen.Password(r)
+		if err != nil {
+			return fmt.Errorf("testvectors: %s: password %d: %w", v.Name, i, err)
+		}
+		if pass != expect {
+			return fmt.Errorf("testvectors: %s: password %d is %q, expected %q", v.Name, i, pass, expect)
+		}
+	}
+
+	if br.Len() != 0 {
+		return fmt.Errorf("testvectors: %s: %d bytes of input were not read", v.Name, br.Len())
+	}
+
+	return nil
+}
+
+// Check checks every vector in f with Vector.Check and every list checksum in f
+// against passit.ListChecksum.
+func (f *File) Check() error {
+	if f.Version != Version {
+		return fmt.Errorf("testvectors: unsupported version %d", f.Version)
+	}
+
+	var errs []error
+	for i := range f.Vectors {
+		errs = append(errs, f.Vectors[i].Check())
+	}
+	names := make([]string, 0, len(f.Lists))
+	for name := range f.Lists {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		errs = append(errs, checkList(name, f.Lists[name]))
+	}
+	return errors.Join(errs...)
+}
+
+// checkList reports whether the list of the stable generator name has the
+// checksum expect.
+func checkList(name, expect string) error {
+	gen, ok := passit.Stable(name)
+	if !ok {
+		return fmt.Errorf("testvectors: unknown list %q", name)
+	}
+
+	sum, err := passit.ListChecksum(gen)
+	if err != nil {
+		return fmt.Errorf("testvectors: list %s: %w", name, err)
+	}
+	if sum != expect {
+		return fmt.Errorf("testvectors: list %s has checksum %s, expected %s", name, sum, expect)
+	}
+	return nil
+}
+
+// Read decodes a File from r.
+func Read(r io.Reader) (*File, error) {
+	var f File
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("testvectors: %w", err)
+	}
+	return &f, nil
+}
+
+// Write encodes f to w as indented JSON.
+func (f *File) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	return enc.Encode(f)
+}
+
+// passwordsPerVector is the number of passwords generated for each vector.
+const passwordsPerVector = 3
+
+// maxInput is the longest input that Generate will use for a vector.
+const maxInput = 1 << 16
+
+// Generate returns the test vectors for every stable generator and a selection of
+// other generators, for each supported Determinism, as generated by passit, and
+// the checksums of the lists of the stable generators.
+//
+// The input for each vector is the start of a stream of SHA-256 hashes of the
+// vector's name followed by a big-endian uint64 counter, cut to the bytes read.
+func Generate() (*File, error) {
+	f := &File{Version: Version, Lists: make(map[string]string)}
+	for _, name := range passit.StableNames() {
+		gen, _ := passit.Stable(name)
+		if sum, err := passit.ListChecksum(gen); err == nil {
+			f.Lists[name] = sum
+		}
+	}
+
+	for _, spec := range specs {
+		gen, err := spec.Spec.Generator()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range []passit.Determinism{
+			passit.DeterminismBytes1,
+			passit.DeterminismBits1,
+		} {
+			v := Vector{
+				Name:        spec.Name + " " + string(d),
+				Determinism: d,
+				Generator:   spec.Spec,
+			}
+
+			stream := inputStream(v.Name)
+			cr := passit.NewCountingReader(bytes.NewReader(stream))
+			r, err := newReader(d, cr)
+			if err != nil {
+				return nil, err
+			}
+
+			for range passwordsPerVector {
+				pass, err := gen.Password(r)
+				if err != nil {
+					return nil, fmt.Errorf("testvectors: %s: %w", v.Name, err)
+				}
+				v.Passwords = append(v.Passwords, pass)
+			}
+
+			v.Input = hex.EncodeToString(stream[:cr.BytesRead()])
+			f.Vectors = append(f.Vectors, v)
+		}
+	}
+	return f, nil
+}
+
+func inputStream(name string) []byte {
+	stream := make([]byte, 0, maxInput)
+	for i := uint64(0); len(stream) < maxInput; i++ {
+		h := sha256.New()
+		h.Write([]byte(name))
+		binary.Write(h, binary.BigEndian, i)
+		stream = h.Sum(stream)
+	}
+	return stream
+}
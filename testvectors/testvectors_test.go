@@ -0,0 +1,148 @@
+package testvectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+func readVectors(t *testing.T) *File {
+	t.Helper()
+
+	r, err := os.Open("vectors.json")
+	require.NoError(t, err)
+	defer r.Close()
+
+	f, err := Read(r)
+	require.NoError(t, err)
+	return f
+}
+
+func TestVectors(t *testing.T) {
+	f := readVectors(t)
+	assert.Equal(t, Version, f.Version)
+	assert.NotEmpty(t, f.Vectors)
+	assert.NotEmpty(t, f.Lists)
+	assert.NoError(t, f.Check())
+}
+
+func TestVectorsUpToDate(t *testing.T) {
+	f, err := Generate()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Write(&buf))
+
+	expect, err := os.ReadFile("vectors.json")
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(expect, buf.Bytes()),
+		"vectors.json is out of date, run go generate")
+
+	names := make(map[string]bool)
+	for _, v := range readVectors(t).Vectors {
+		assert.Falsef(t, names[v.Name], "duplicate vector %q", v.Name)
+		names[v.Name] = true
+	}
+}
+
+func TestVectorsStable(t *testing.T) {
+	f := readVectors(t)
+
+	// There are vectors for every stable generator and supported Determinism.
+	have := make(map[string]bool)
+	for _, v := range f.Vectors {
+		have[v.Generator.Gen+" "+string(v.Determinism)] = true
+	}
+	for _, name := range passit.StableNames() {
+		for _, d := range []passit.Determinism{passit.DeterminismBytes1, passit.DeterminismBits1} {
+			assert.Truef(t, have[name+" "+string(d)], "missing vector for %s %s", name, d)
+		}
+	}
+}
+
+func TestVectorCheck(t *testing.T) {
+	v := Vector{
+		Name:        "test",
+		Determinism: passit.DeterminismBytes1,
+		Generator:   &Spec{Gen: "Repeat", Of: &Spec{Gen: "Digit"}, Count: 3},
+		Input:       "010203fa04",
+		Passwords:   []string{"123", "456"},
+	}
+
+	// 0xfa is rejected as it would bias the digits.
+	assert.EqualError(t, v.Check(), "testvectors: test: password 1: passit: failed to read entropy: EOF")
+
+	v.Input = "010203fa040506"
+	assert.NoError(t, v.Check())
+
+	v.Passwords[1] = "457"
+	assert.EqualError(t, v.Check(), `testvectors: test: password 1 is "456", expected "457"`)
+	v.Passwords[1] = "456"
+
+	v.Input += "07"
+	assert.EqualError(t, v.Check(), "testvectors: test: 1 bytes of input were not read")
+
+	v.Input = "zz"
+	assert.ErrorContains(t, v.Check(), "testvectors: test: invalid input: ")
+
+	v.Input = ""
+	v.Determinism = passit.DeterminismSource1
+	assert.EqualError(t, v.Check(), `testvectors: unsupported determinism "passit/source/1"`)
+
+	v.Generator = nil
+	assert.EqualError(t, v.Check(), "testvectors: test is missing generator")
+
+	f := &File{Version: 2}
+	assert.EqualError(t, f.Check(), "testvectors: unsupported version 2")
+}
+
+func TestListsCheck(t *testing.T) {
+	f := &File{Version: Version, Lists: map[string]string{
+		"Digit": "0000000000000000000000000000000000000000000000000000000000000000",
+	}}
+	assert.EqualError(t, f.Check(), "testvectors: list Digit has checksum "+
+		"7427877c40fb0361401248f9c96abe6117396bc6ab16811b5b1706274c02443e, expected "+
+		"0000000000000000000000000000000000000000000000000000000000000000")
+
+	f.Lists = map[string]string{"Unknown": ""}
+	assert.EqualError(t, f.Check(), `testvectors: unknown list "Unknown"`)
+
+	f.Lists = map[string]string{"SpectreLong": ""}
+	assert.EqualError(t, f.Check(), "testvectors: list SpectreLong: passit: generator is not a list")
+}
+
+func TestSpecErrors(t *testing.T) {
+	for _, tc := range []struct {
+		spec *Spec
+		err  string
+	}{
+		{&Spec{Gen: "Unknown"}, `testvectors: unknown generator "Unknown"`},
+		{&Spec{Gen: "EmojiLatest"}, `testvectors: unknown generator "EmojiLatest"`},
+		{&Spec{Gen: "Repeat", Count: 2}, "testvectors: Repeat is missing of"},
+		{&Spec{Gen: "RepeatGen", Of: &Spec{Gen: "Digit"}, Count: 2}, "testvectors: RepeatGen is missing sepGen"},
+		{&Spec{Gen: "Join", Gens: []*Spec{{Gen: "Digit"}, nil}}, "testvectors: generator is missing spec"},
+		{&Spec{Gen: "ConstrainLength", Of: &Spec{Gen: "Digit"}, Measure: "words"}, `testvectors: unknown length measure "words"`},
+		{&Spec{Gen: "BIP39Mnemonic", Count: 13}, "testvectors: invalid BIP39Mnemonic: passit: BIP-39 mnemonic must be 12, 15, 18, 21 or 24 words"},
+		{&Spec{Gen: "Regexp", Pattern: "("}, "error parsing regexp: missing closing ): `(`"},
+		{&Spec{Gen: "RepeatLength", Of: &Spec{Gen: "EFFLargeWordlist"}, Min: 2, Max: 1}, "passit: count must be positive"},
+	} {
+		_, err := tc.spec.Generator()
+		assert.EqualErrorf(t, err, tc.err, "%+v", tc.spec)
+	}
+}
+
+func TestSpecJSON(t *testing.T) {
+	spec := &Spec{Gen: "Repeat", Of: &Spec{Gen: "EFFLargeWordlist"}, Sep: "-", Count: 4}
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"gen":"Repeat","of":{"gen":"EFFLargeWordlist"},"sep":"-","count":4}`, string(b))
+
+	var got Spec
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, spec, &got)
+}
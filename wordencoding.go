@@ -0,0 +1,223 @@
+package passit
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// WordEncoding is a reversible encoding of arbitrary bytes to a sequence of words
+// from a wordlist, with a checksum to detect mistyped or missing words.
+//
+// Unlike the Generators in this package, which map random bytes to a password in a
+// way that can't be reversed, a WordEncoding is designed for rendering secrets like
+// recovery keys and backups as words that can be decoded back to the original
+// bytes.
+//
+// Each word encodes Bits bits. The encoded bit stream consists of the data, a
+// single 1 bit, zero or more 0 bits of padding and finally a checksum of Bits bits
+// taken from the start of the SHA-256 hash of the data. The padding is chosen so
+// that the stream is a multiple of Bits long.
+type WordEncoding struct {
+	words []string
+	index map[string]int
+	bits  int
+	sep   string
+}
+
+// NewWordEncoding returns a WordEncoding that uses the words of gen, separated by
+// sep.
+//
+// gen must be a wordlist accepted by AnalyzeWordlist and contain at least two
+// words. If the number of words isn't a power of two, only the first 2^k words are
+// used, where 2^k is the largest power of two not greater than the number of words.
+// For instance, OrchardStreetMediumV2 contains 8,192 words and encodes 13 bits per
+// word, while EFFLargeWordlist is trimmed from 7,776 words to 4,096 words and
+// encodes 12 bits per word.
+//
+// It returns an error if sep is empty, if any of the used words are duplicated or
+// empty, or if any word contains sep.
+func NewWordEncoding(gen Generator, sep string) (*WordEncoding, error) {
+	wg, ok := gen.(wordlistGenerator)
+	if !ok {
+		return nil, errors.New("passit: generator is not a wordlist")
+	}
+	if sep == "" {
+		return nil, errors.New("passit: word encoding separator must not be empty")
+	}
+
+	words := wg.wordlist()
+	if len(words) < 2 {
+		return nil, errors.New("passit: word encoding requires at least two words")
+	}
+
+	bitLen := bits.Len(uint(len(words))) - 1
+	words = words[:1<<bitLen]
+
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		switch {
+		case word == "":
+			return nil, errors.New("passit: word encoding wordlist contains an empty word")
+		case strings.Contains(word, sep):
+			return nil, fmt.Errorf("passit: word encoding wordlist word %q contains separator", word)
+		}
+		if _, dup := index[word]; dup {
+			return nil, fmt.Errorf("passit: word encoding wordlist contains duplicate word %q", word)
+		}
+		index[word] = i
+	}
+
+	return &WordEncoding{words, index, bitLen, sep}, nil
+}
+
+// Bits returns the number of bits encoded by each word.
+func (we *WordEncoding) Bits() int {
+	return we.bits
+}
+
+// EncodedLen returns the number of words needed to encode n bytes.
+func (we *WordEncoding) EncodedLen(n int) int {
+	return (8*n + 1 + we.bits + we.bits - 1) / we.bits
+}
+
+// EncodeToString returns the words encoding src separated by the separator.
+func (we *WordEncoding) EncodeToString(src []byte) string {
+	sum := sha256.Sum256(src)
+
+	bw := wordBitWriter{
+		words: make([]int, 0, we.EncodedLen(len(src))),
+		bits:  we.bits,
+	}
+	for _, b := range src {
+		bw.write(uint64(b), 8)
+	}
+
+	bw.write(1, 1)
+	bw.write(0, (we.bits-bw.n%we.bits)%we.bits)
+	bw.write(checksumBits(sum[:], we.bits), we.bits)
+
+	var sb strings.Builder
+	for i, idx := range bw.words {
+		if i > 0 {
+			sb.WriteString(we.sep)
+		}
+		sb.WriteString(we.words[idx])
+	}
+
+	return sb.String()
+}
+
+// DecodeString returns the bytes represented by the words in s.
+//
+// Leading and trailing whitespace around each word is ignored. It returns an error
+// that identifies the offending word if any word isn't in the wordlist, and an
+// error if the checksum doesn't match, which usually means a word was mistyped,
+// omitted or swapped with another.
+func (we *WordEncoding) DecodeString(s string) ([]byte, error) {
+	parts := strings.Split(strings.TrimSpace(s), we.sep)
+
+	// The smallest valid encoding contains the 1 bit and the checksum.
+	if len(parts)*we.bits < 1+we.bits {
+		return nil, errors.New("passit: word encoding too short")
+	}
+
+	br := wordBitReader{
+		words: make([]int, len(parts)),
+		bits:  we.bits,
+	}
+	for i, word := range parts {
+		word = strings.TrimSpace(word)
+		idx, ok := we.index[word]
+		switch {
+		case word == "":
+			return nil, fmt.Errorf("passit: word %d is empty", i+1)
+		case !ok:
+			return nil, fmt.Errorf("passit: word %d %q is not in the wordlist", i+1, word)
+		}
+		br.words[i] = idx
+	}
+
+	// Everything before the checksum is the data, a 1 bit and then the 0 bit
+	// padding.
+	payloadBits := len(parts)*we.bits - we.bits
+
+	// The padding is always shorter than a word, so the 1 bit must be within the
+	// final Bits bits of the payload.
+	var dataBits int
+	for i := payloadBits - 1; ; i-- {
+		if i < 0 || i < payloadBits-we.bits {
+			return nil, errors.New("passit: word encoding has invalid padding")
+		}
+		if br.bit(i) == 1 {
+			dataBits = i
+			break
+		}
+	}
+	if dataBits%8 != 0 {
+		return nil, errors.New("passit: word encoding has invalid padding")
+	}
+
+	dst := make([]byte, dataBits/8)
+	for i := range dst {
+		dst[i] = byte(br.read(i*8, 8))
+	}
+
+	sum := sha256.Sum256(dst)
+	if br.read(payloadBits, we.bits) != checksumBits(sum[:], we.bits) {
+		return nil, errors.New("passit: word encoding checksum mismatch")
+	}
+
+	return dst, nil
+}
+
+// checksumBits returns the first n bits of sum.
+func checksumBits(sum []byte, n int) uint64 {
+	var v uint64
+	for i := range n {
+		v = v<<1 | uint64(sum[i/8]>>(7-i%8)&1)
+	}
+
+	return v
+}
+
+// wordBitWriter packs a big-endian bit stream into word indexes of bits length.
+type wordBitWriter struct {
+	words []int
+	bits  int
+
+	cur int
+	n   int // total bits written
+}
+
+func (bw *wordBitWriter) write(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		bw.cur = bw.cur<<1 | int(v>>i&1)
+		bw.n++
+		if bw.n%bw.bits == 0 {
+			bw.words = append(bw.words, bw.cur)
+			bw.cur = 0
+		}
+	}
+}
+
+// wordBitReader reads a big-endian bit stream from word indexes of bits length.
+type wordBitReader struct {
+	words []int
+	bits  int
+}
+
+func (br *wordBitReader) bit(i int) int {
+	return br.words[i/br.bits] >> (br.bits - 1 - i%br.bits) & 1
+}
+
+func (br *wordBitReader) read(off, n int) uint64 {
+	var v uint64
+	for i := range n {
+		v = v<<1 | uint64(br.bit(off+i))
+	}
+
+	return v
+}
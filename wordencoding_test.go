@@ -0,0 +1,105 @@
+package passit
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		bits   int
+		expect string
+	}{
+		{"OrchardStreetMediumV2", OrchardStreetMediumV2, 13, "gifted coaching examines royal rituals attractive denotes shanghai correspond generates exotic"},
+		{"OrchardStreetLongV2", OrchardStreetLongV2, 14, "flock earnest doctrine cheating countless authorship nun cheeks civilian morphology embarked"},
+		{"EFFLargeWordlist", EFFLargeWordlist, 12, "deflator evil imprudent moonwalk brunch grab conducive flyable helper claim bullfrog cranium"},
+		{"EFFShortWordlist1", EFFShortWordlist1, 10, "fence maker path crank hub name ounce baton sleek fend lung armor civic drove"},
+	} {
+		we, err := NewWordEncoding(tc.gen, " ")
+		if !assert.NoErrorf(t, err, "NewWordEncoding: %s", tc.name) {
+			continue
+		}
+
+		assert.Equalf(t, tc.bits, we.Bits(), "Bits: %s", tc.name)
+
+		src := make([]byte, 16)
+		_, err = io.ReadFull(newTestRand(), src)
+		require.NoError(t, err)
+
+		enc := we.EncodeToString(src)
+		assert.Equalf(t, tc.expect, enc, "EncodeToString: %s", tc.name)
+		assert.Equalf(t, we.EncodedLen(len(src)), strings.Count(enc, " ")+1, "EncodedLen: %s", tc.name)
+
+		dec, err := we.DecodeString(enc)
+		if assert.NoErrorf(t, err, "DecodeString: %s", tc.name) {
+			assert.Equalf(t, src, dec, "DecodeString: %s", tc.name)
+		}
+	}
+}
+
+func TestWordEncodingRoundTrip(t *testing.T) {
+	tr := newTestRand()
+
+	for _, gen := range []Generator{OrchardStreetMediumV2, EFFLargeWordlist, EFFShortWordlist2, FromSlice("a", "b"), FromSlice("a", "b", "c", "d")} {
+		we, err := NewWordEncoding(gen, ".")
+		require.NoError(t, err)
+
+		for n := range 70 {
+			src := make([]byte, n)
+			_, err := io.ReadFull(tr, src)
+			require.NoError(t, err)
+
+			enc := we.EncodeToString(src)
+			assert.Equalf(t, we.EncodedLen(n), strings.Count(enc, ".")+1, "EncodedLen(%d)", n)
+
+			dec, err := we.DecodeString(enc)
+			if assert.NoErrorf(t, err, "DecodeString(%q)", enc) {
+				assert.Equalf(t, src, dec, "DecodeString(%q)", enc)
+			}
+		}
+	}
+}
+
+func TestWordEncodingErrors(t *testing.T) {
+	for _, tc := range []struct {
+		gen Generator
+		sep string
+		err string
+	}{
+		{LatinLower, " ", "passit: generator is not a wordlist"},
+		{EFFLargeWordlist, "", "passit: word encoding separator must not be empty"},
+		{FromSlice("a", "a"), " ", `passit: word encoding wordlist contains duplicate word "a"`},
+		{FromSlice("a", ""), " ", "passit: word encoding wordlist contains an empty word"},
+		{FromSlice("a", "b c"), " ", `passit: word encoding wordlist word "b c" contains separator`},
+		{String("a"), " ", "passit: generator is not a wordlist"},
+	} {
+		_, err := NewWordEncoding(tc.gen, tc.sep)
+		assert.EqualError(t, err, tc.err)
+	}
+
+	we, err := NewWordEncoding(OrchardStreetMediumV2, " ")
+	require.NoError(t, err)
+
+	const valid = "gifted coaching examines royal rituals attractive denotes shanghai correspond generates exotic"
+	_, err = we.DecodeString("  " + valid + "\n")
+	assert.NoError(t, err, "surrounding whitespace")
+
+	for _, tc := range []struct{ s, err string }{
+		{"", "passit: word encoding too short"},
+		{"gifted", "passit: word encoding too short"},
+		{"gifted coaching examines roayl rituals attractive denotes shanghai correspond generates exotic", `passit: word 4 "roayl" is not in the wordlist`},
+		{"gifted coaching  examines royal rituals attractive denotes shanghai correspond generates exotic", "passit: word 3 is empty"},
+		{"gifted coaching examines royal rituals attractive denotes shanghai correspond generates generates", "passit: word encoding checksum mismatch"},
+		{"gifted coaching examines rituals royal attractive denotes shanghai correspond generates exotic", "passit: word encoding checksum mismatch"},
+		{"gifted coaching examines royal rituals attractive denotes shanghai correspond exotic", "passit: word encoding has invalid padding"},
+	} {
+		_, err := we.DecodeString(tc.s)
+		assert.EqualErrorf(t, err, tc.err, "DecodeString(%q)", tc.s)
+	}
+}
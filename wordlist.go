@@ -0,0 +1,232 @@
+package passit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Wordlist is a Generator that returns a random word from a validated list of
+// words. It also provides metadata about the list.
+//
+// A Wordlist is created by NewWordlist or NewWordlistFS.
+type Wordlist struct {
+	words []string
+
+	minLen, maxLen int
+
+	decodable decodableCache
+}
+
+// NewWordlist parses a wordlist from r and returns a Wordlist that returns a
+// random word from it.
+//
+// The list must contain one word per line. Lines may optionally be prefixed with a
+// diceware-style number followed by whitespace (e.g. "11111\tabacus") as is used
+// by the EFF wordlists, in which case every line must be numbered. Leading and
+// trailing whitespace is removed from each word and words are normalised to
+// Unicode Normalization Form C (NFC).
+//
+// It returns an error if the list is empty, if any line is empty, if any word is
+// not valid UTF-8 or if any word appears more than once after normalisation.
+//
+// The order of the words is preserved and the returned Generator is deterministic
+// for a given list.
+func NewWordlist(r io.Reader) (*Wordlist, error) {
+	var (
+		words    []string
+		seen     = make(map[string]int)
+		numbered bool
+	)
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		word, hasIdx := cutDicewareIndex(sc.Text())
+		if line == 1 {
+			numbered = hasIdx
+		} else if hasIdx != numbered {
+			return nil, fmt.Errorf("passit: wordlist line %d: mixed numbered and unnumbered lines", line)
+		}
+
+		word = strings.TrimSpace(word)
+		switch {
+		case word == "":
+			return nil, fmt.Errorf("passit: wordlist line %d: empty word", line)
+		case !utf8.ValidString(word):
+			return nil, fmt.Errorf("passit: wordlist line %d: word is not valid UTF-8", line)
+		}
+
+		word = norm.NFC.String(word)
+		if prev, dup := seen[word]; dup {
+			return nil, fmt.Errorf("passit: wordlist line %d: duplicate word %q (first seen on line %d)", line, word, prev)
+		}
+		seen[word] = line
+
+		words = append(words, word)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("passit: failed to read wordlist: %w", err)
+	}
+
+	if len(words) == 0 {
+		return nil, errors.New("passit: wordlist contains no words")
+	}
+
+	return newWordlist(words), nil
+}
+
+// NewWordlistFS is like NewWordlist but reads the wordlist from the named file in
+// fsys.
+func NewWordlistFS(fsys fs.FS, name string) (*Wordlist, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("passit: failed to open wordlist: %w", err)
+	}
+	defer f.Close()
+
+	return NewWordlist(f)
+}
+
+// cutDicewareIndex removes a diceware number from the start of line. If line
+// doesn't start with digits followed by whitespace, hasIdx is false and word is
+// line.
+func cutDicewareIndex(line string) (word string, hasIdx bool) {
+	end := strings.IndexFunc(line, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if end <= 0 || (line[end] != '\t' && line[end] != ' ') {
+		// Either the line doesn't start with digits, is entirely digits or
+		// the digits aren't followed by whitespace. Treat it as a word.
+		return line, false
+	}
+
+	return line[end+1:], true
+}
+
+// newWordlist returns a Wordlist for words. words must already be validated and
+// must not be modified after calling newWordlist.
+func newWordlist(words []string) *Wordlist {
+	wl := &Wordlist{words: words, minLen: math.MaxInt}
+	for _, word := range words {
+		n := utf8.RuneCountInString(word)
+		wl.minLen = min(wl.minLen, n)
+		wl.maxLen = max(wl.maxLen, n)
+	}
+
+	return wl
+}
+
+func (wl *Wordlist) wordlist() []string {
+	return wl.words
+}
+
+func (wl *Wordlist) uniquelyDecodable() bool {
+	return wl.decodable.uniquelyDecodable(wl.words)
+}
+
+// Password implements Generator.
+func (wl *Wordlist) Password(r io.Reader) (string, error) {
+	return readSliceN(r, wl.words)
+}
+
+// Len returns the number of words in the list.
+func (wl *Wordlist) Len() int {
+	return len(wl.words)
+}
+
+// Words returns a copy of the words in the list in their original order.
+func (wl *Wordlist) Words() []string {
+	return slices.Clone(wl.words)
+}
+
+// BitsPerWord returns the entropy, in bits, of a single word selected uniformly
+// at random from the list.
+func (wl *Wordlist) BitsPerWord() float64 {
+	return math.Log2(float64(len(wl.words)))
+}
+
+// MinWordLen returns the length, in runes, of the shortest word in the list.
+func (wl *Wordlist) MinWordLen() int {
+	return wl.minLen
+}
+
+// MaxWordLen returns the length, in runes, of the longest word in the list.
+func (wl *Wordlist) MaxWordLen() int {
+	return wl.maxLen
+}
+
+// FilterWordlist returns a Wordlist containing only the words of gen for which
+// keep reports true. The order of the words is preserved and duplicate words are
+// removed.
+//
+// Unlike RejectionSample, the returned Wordlist selects uniformly from the
+// filtered words directly, so it never needs to discard generated words and
+// consumes a predictable amount of entropy. The entropy of each word is reported
+// by BitsPerWord.
+//
+// WordLen, WordExcludes and WordHasPrefix return common filters for keep.
+//
+// gen must be a wordlist accepted by AnalyzeWordlist. It returns an error if gen
+// isn't a wordlist or if no words remain after filtering.
+func FilterWordlist(gen Generator, keep func(word string) bool) (*Wordlist, error) {
+	wg, ok := gen.(wordlistGenerator)
+	if !ok {
+		return nil, errors.New("passit: generator is not a wordlist")
+	}
+
+	var words []string
+	seen := make(map[string]struct{})
+	for _, word := range wg.wordlist() {
+		if _, dup := seen[word]; dup || !keep(word) {
+			continue
+		}
+
+		seen[word] = struct{}{}
+		words = append(words, word)
+	}
+
+	if len(words) == 0 {
+		return nil, errors.New("passit: wordlist contains no words")
+	}
+
+	return newWordlist(words), nil
+}
+
+// WordLen returns a function for use with FilterWordlist that reports whether a
+// word is between min and max runes long, inclusive.
+func WordLen(min, max int) func(word string) bool {
+	return func(word string) bool {
+		n := utf8.RuneCountInString(word)
+		return n >= min && n <= max
+	}
+}
+
+// WordExcludes returns a function for use with FilterWordlist that reports
+// whether a word contains none of the runes in chars.
+func WordExcludes(chars string) func(word string) bool {
+	return func(word string) bool {
+		return !strings.ContainsAny(word, chars)
+	}
+}
+
+// WordHasPrefix returns a function for use with FilterWordlist that reports
+// whether a word begins with any of prefixes.
+func WordHasPrefix(prefixes ...string) func(word string) bool {
+	prefixes = slices.Clone(prefixes)
+	return func(word string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(word, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,156 @@
+package passit
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit/internal/wordlist"
+)
+
+func TestNewWordlist(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		list   string
+		words  []string
+		expect string
+	}{
+		{"plain", "alpha\nbravo\ncharlie\ndelta\n", []string{"alpha", "bravo", "charlie", "delta"}, "charlie bravo delta alpha delta charlie alpha delta"},
+		{"no trailing newline", "alpha\nbravo\ncharlie\ndelta", []string{"alpha", "bravo", "charlie", "delta"}, "charlie bravo delta alpha delta charlie alpha delta"},
+		{"CRLF", "alpha\r\nbravo\r\ncharlie\r\ndelta\r\n", []string{"alpha", "bravo", "charlie", "delta"}, "charlie bravo delta alpha delta charlie alpha delta"},
+		{"diceware", "11111\talpha\n11112\tbravo\n11113\tcharlie\n11114\tdelta\n", []string{"alpha", "bravo", "charlie", "delta"}, "charlie bravo delta alpha delta charlie alpha delta"},
+		{"diceware spaces", "1111 alpha\n1112 bravo\n1113 charlie\n1114 delta\n", []string{"alpha", "bravo", "charlie", "delta"}, "charlie bravo delta alpha delta charlie alpha delta"},
+		{"NFC", "café\nnaïve\n", []string{"café", "naïve"}, "café naïve naïve café naïve café café naïve"},
+		{"digits", "1st\n2nd\n", []string{"1st", "2nd"}, "1st 2nd 2nd 1st 2nd 1st 1st 2nd"},
+	} {
+		wl, err := NewWordlist(strings.NewReader(tc.list))
+		if !assert.NoErrorf(t, err, "NewWordlist: %s", tc.name) {
+			continue
+		}
+
+		assert.Equalf(t, tc.words, wl.Words(), "Words: %s", tc.name)
+		assert.Equalf(t, len(tc.words), wl.Len(), "Len: %s", tc.name)
+
+		tr := newTestRand()
+
+		pass, err := Repeat(wl, " ", 8).Password(tr)
+		if assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+		}
+	}
+
+	for _, tc := range []struct {
+		name, list, err string
+	}{
+		{"empty", "", "passit: wordlist contains no words"},
+		{"empty line", "alpha\n\nbravo\n", "passit: wordlist line 2: empty word"},
+		{"whitespace line", "alpha\n \t \nbravo\n", "passit: wordlist line 2: empty word"},
+		{"empty diceware word", "11111\talpha\n11112\t\n", "passit: wordlist line 2: empty word"},
+		{"duplicate", "alpha\nbravo\nalpha\n", `passit: wordlist line 3: duplicate word "alpha" (first seen on line 1)`},
+		{"NFC duplicate", "café\ncafé\n", `passit: wordlist line 2: duplicate word "café" (first seen on line 1)`},
+		{"invalid UTF-8", "alpha\n\xff\n", "passit: wordlist line 2: word is not valid UTF-8"},
+		{"mixed numbering", "11111\talpha\nbravo\n", "passit: wordlist line 2: mixed numbered and unnumbered lines"},
+		{"mixed numbering unnumbered first", "alpha\n11112\tbravo\n", "passit: wordlist line 2: mixed numbered and unnumbered lines"},
+	} {
+		_, err := NewWordlist(strings.NewReader(tc.list))
+		assert.EqualErrorf(t, err, tc.err, "NewWordlist: %s", tc.name)
+	}
+}
+
+func TestNewWordlistFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"list.txt": &fstest.MapFile{Data: []byte("11111\tabacus\n11112\tabdomen\n11113\tabdominal\n11114\tabide\n")},
+	}
+
+	wl, err := NewWordlistFS(fsys, "list.txt")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"abacus", "abdomen", "abdominal", "abide"}, wl.Words())
+	assert.Equal(t, 4, wl.Len())
+	assert.Equal(t, 2.0, wl.BitsPerWord())
+	assert.Equal(t, 5, wl.MinWordLen())
+	assert.Equal(t, 9, wl.MaxWordLen())
+
+	_, err = NewWordlistFS(fsys, "missing.txt")
+	assert.ErrorContains(t, err, "passit: failed to open wordlist: ")
+}
+
+func TestWordlistMetadata(t *testing.T) {
+	wl, err := NewWordlist(strings.NewReader(wordlist.EFFLargeWordlist))
+	require.NoError(t, err)
+
+	assert.Equal(t, 7776, wl.Len())
+	assert.InDelta(t, 12.925, wl.BitsPerWord(), 0.0005)
+	assert.Equal(t, 3, wl.MinWordLen())
+	assert.Equal(t, 9, wl.MaxWordLen())
+
+	tr := newTestRand()
+
+	pass, err := Repeat(wl, " ", 8).Password(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "reprint wool pantry unworried mummify veneering securely munchkin", pass,
+		"should match EFFLargeWordlist")
+}
+
+func TestFilterWordlist(t *testing.T) {
+	_, err := FilterWordlist(LatinLower, WordLen(1, 1))
+	assert.EqualError(t, err, "passit: generator is not a wordlist")
+
+	_, err = FilterWordlist(EFFLargeWordlist, WordLen(20, 30))
+	assert.EqualError(t, err, "passit: wordlist contains no words")
+
+	for _, tc := range []struct {
+		name   string
+		gen    Generator
+		keep   func(string) bool
+		len    int
+		bits   float64
+		expect string
+	}{
+		{"EFFLargeWordlist 4-7", EFFLargeWordlist, WordLen(4, 7), 4358, 12.089, "rural lavish cobweb lettuce manhole elude bulgur saddled"},
+		{"STS10Wordlist no qzxj", STS10Wordlist, WordExcludes("qzxj"), 17193, 14.070, "bathroom tectonic causes flounder sight real thinking imposing"},
+		{"OrchardStreetLongV2 both", OrchardStreetLongV2, func(word string) bool {
+			return WordLen(4, 6)(word) && WordExcludes("qzxj")(word)
+		}, 4536, 12.147, "china willed stole flank fauna beers wider pagan"},
+		{"EFFLargeWordlist prefix", EFFLargeWordlist, WordHasPrefix("ab", "st"), 231, 7.852, "sterling statue stuffed stony stamp starlight stonework stature"},
+		{"FromSlice duplicates", FromSlice("a", "b", "a", "cc"), WordLen(1, 1), 2, 1, "a b b a b a a b"},
+	} {
+		wl, err := FilterWordlist(tc.gen, tc.keep)
+		if !assert.NoErrorf(t, err, "FilterWordlist: %s", tc.name) {
+			continue
+		}
+
+		assert.Equalf(t, tc.len, wl.Len(), "Len: %s", tc.name)
+		assert.InDeltaf(t, tc.bits, wl.BitsPerWord(), 0.0005, "BitsPerWord: %s", tc.name)
+		for _, word := range wl.Words() {
+			assert.Truef(t, tc.keep(word), "%q should have been filtered: %s", word, tc.name)
+		}
+
+		tr := newTestRand()
+
+		pass, err := Repeat(wl, " ", 8).Password(tr)
+		if assert.NoErrorf(t, err, "Password: %s", tc.name) {
+			assert.Equalf(t, tc.expect, pass, "Password: %s", tc.name)
+		}
+	}
+}
+
+func TestWordHasPrefix(t *testing.T) {
+	hasPrefix := WordHasPrefix("ab", "st")
+	assert.True(t, hasPrefix("abacus"))
+	assert.True(t, hasPrefix("stamp"))
+	assert.True(t, hasPrefix("ab"))
+	assert.False(t, hasPrefix("cabin"))
+	assert.False(t, hasPrefix("a"))
+
+	assert.False(t, WordHasPrefix()("abacus"))
+	assert.True(t, WordHasPrefix("")("abacus"))
+
+	wl, err := FilterWordlist(EFFLargeWordlist, WordHasPrefix("ab"))
+	require.NoError(t, err)
+	for _, word := range wl.Words() {
+		assert.Truef(t, strings.HasPrefix(word, "ab"), "%q", word)
+	}
+}
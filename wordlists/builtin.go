@@ -0,0 +1,193 @@
+package wordlists
+
+import "go.tmthrgd.dev/passit"
+
+const (
+	orchardStreetSource  = "https://github.com/sts10/orchard-street-wordlists"
+	orchardStreetLicense = "CC BY-SA 4.0"
+
+	effSource  = "https://www.eff.org/dice"
+	effLicense = "CC BY 3.0 US"
+
+	// bip39Source is the bitcoin/bips commit the BIP-39 wordlists were taken
+	// from. The wordlist files have no license header; bip-0039.mediawiki, which
+	// they are part of, declares "License: MIT".
+	bip39Source  = "https://github.com/bitcoin/bips/blob/9783d61f1b9c/bip-0039/"
+	bip39License = "MIT"
+
+	lexiconSource  = "https://pkg.go.dev/go.tmthrgd.dev/passit#NewSentence"
+	lexiconLicense = "BSD 3-Clause"
+
+	emojiLicense = "Unicode License v3"
+)
+
+func init() {
+	for _, wl := range []Wordlist{
+		{
+			Name:        "orchard:medium",
+			Description: "Sam Schlinkert's Orchard Street Medium List",
+			Size:        8192,
+			Source:      orchardStreetSource,
+			License:     orchardStreetLicense,
+			Generator:   passit.OrchardStreetMediumV2,
+		},
+		{
+			Name:        "orchard:long",
+			Aliases:     []string{"orchard"},
+			Description: "Sam Schlinkert's Orchard Street Long List",
+			Size:        17576,
+			Source:      orchardStreetSource,
+			License:     orchardStreetLicense,
+			Generator:   passit.OrchardStreetLongV2,
+		},
+		{
+			Name:        "orchard:alpha",
+			Description: "Sam Schlinkert's Orchard Street Alpha List",
+			Size:        1296,
+			Source:      orchardStreetSource,
+			License:     orchardStreetLicense,
+			Generator:   passit.OrchardStreetAlphaV2,
+		},
+		{
+			Name:        "orchard:qwerty",
+			Description: "Sam Schlinkert's Orchard Street QWERTY List",
+			Size:        1296,
+			Source:      orchardStreetSource,
+			License:     orchardStreetLicense,
+			Generator:   passit.OrchardStreetQWERTYV2,
+		},
+		{
+			Name:        "sts10",
+			Description: "Sam Schlinkert's '1Password Replacement List' (not uniquely decodable)",
+			Size:        18208,
+			Source:      "https://github.com/sts10/generated-wordlists/tree/e0daeebbffbb/lists/1password-replacement",
+			License:     "CC BY 3.0",
+			Generator:   passit.STS10Wordlist,
+		},
+		{
+			Name:        "eff:large",
+			Aliases:     []string{"eff"},
+			Description: "EFF Large Wordlist for Passphrases",
+			Size:        7776,
+			Source:      effSource,
+			License:     effLicense,
+			Generator:   passit.EFFLargeWordlist,
+		},
+		{
+			Name:        "eff:short1",
+			Description: "EFF Short Wordlist for Passphrases #1",
+			Size:        1296,
+			Source:      effSource,
+			License:     effLicense,
+			Generator:   passit.EFFShortWordlist1,
+		},
+		{
+			Name:        "eff:short2",
+			Description: "EFF Short Wordlist for Passphrases #2",
+			Size:        1296,
+			Source:      effSource,
+			License:     effLicense,
+			Generator:   passit.EFFShortWordlist2,
+		},
+		{
+			Name:        "bip39:english",
+			Aliases:     []string{"bip39"},
+			Description: "BIP-39 English wordlist (not uniquely decodable)",
+			Size:        2048,
+			Source:      bip39Source + "english.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39English,
+		},
+		{
+			Name:        "bip39:french",
+			Description: "BIP-39 French wordlist",
+			Size:        2048,
+			Source:      bip39Source + "french.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39French,
+		},
+		{
+			Name:        "bip39:french-ascii",
+			Description: "BIP-39 French wordlist without accents",
+			Size:        2048,
+			Source:      bip39Source + "french.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39FrenchASCII,
+		},
+		{
+			Name:        "bip39:spanish",
+			Description: "BIP-39 Spanish wordlist (not uniquely decodable)",
+			Size:        2048,
+			Source:      bip39Source + "spanish.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39Spanish,
+		},
+		{
+			Name:        "bip39:spanish-ascii",
+			Description: "BIP-39 Spanish wordlist without accents (not uniquely decodable)",
+			Size:        2048,
+			Source:      bip39Source + "spanish.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39SpanishASCII,
+		},
+		{
+			Name:        "bip39:italian",
+			Description: "BIP-39 Italian wordlist",
+			Size:        2048,
+			Source:      bip39Source + "italian.txt",
+			License:     bip39License,
+			Generator:   passit.BIP39Italian,
+		},
+		{
+			Name:        "lexicon:adjective",
+			Description: "English adjectives for sentence passphrases",
+			Size:        512,
+			Source:      lexiconSource,
+			License:     lexiconLicense,
+			Generator:   passit.LexiconAdjective,
+		},
+		{
+			Name:        "lexicon:noun",
+			Description: "English singular nouns for sentence passphrases",
+			Size:        1024,
+			Source:      lexiconSource,
+			License:     lexiconLicense,
+			Generator:   passit.LexiconNoun,
+		},
+		{
+			Name:        "lexicon:verb",
+			Description: "English past tense verbs for sentence passphrases",
+			Size:        256,
+			Source:      lexiconSource,
+			License:     lexiconLicense,
+			Generator:   passit.LexiconVerb,
+		},
+		{
+			Name:        "lexicon:adverb",
+			Description: "English adverbs for sentence passphrases",
+			Size:        256,
+			Source:      lexiconSource,
+			License:     lexiconLicense,
+			Generator:   passit.LexiconAdverb,
+		},
+		{
+			Name:        "emoji:13",
+			Description: "Unicode 13.0 fully-qualified emoji",
+			Size:        3295,
+			Source:      "https://www.unicode.org/Public/emoji/13.0/emoji-test.txt",
+			License:     emojiLicense,
+			Generator:   passit.Emoji13,
+		},
+		{
+			Name:        "emoji:15",
+			Aliases:     []string{"emoji"},
+			Description: "Unicode 15.0 fully-qualified emoji",
+			Size:        3655,
+			Source:      "https://www.unicode.org/Public/emoji/15.0/emoji-test.txt",
+			License:     emojiLicense,
+			Generator:   passit.Emoji15,
+		},
+	} {
+		Register(wl)
+	}
+}
@@ -0,0 +1,146 @@
+// Package wordlists provides a registry of named wordlists for use with passit.
+//
+// Every wordlist embedded in passit is registered by this package along with
+// metadata describing it. Applications can register their own wordlists with
+// Register so that they can be looked up by name alongside the embedded lists.
+package wordlists
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.tmthrgd.dev/passit"
+)
+
+// Wordlist describes a registered wordlist.
+type Wordlist struct {
+	// Name is the canonical name of the wordlist, like "orchard:long".
+	Name string
+
+	// Aliases are alternative names that the wordlist can be looked up by.
+	Aliases []string
+
+	// Description is a short human readable description of the wordlist.
+	Description string
+
+	// Size is the number of words in the wordlist.
+	Size int
+
+	// BitsPerWord is the entropy, in bits, of a single word selected from the
+	// wordlist.
+	BitsPerWord float64
+
+	// Source is a URL where the wordlist was taken from. It may be empty.
+	Source string
+
+	// License is the license that the wordlist is distributed under. It may be
+	// empty if the license is unknown.
+	License string
+
+	// Generator returns a random word from the wordlist.
+	Generator passit.Generator
+}
+
+var (
+	mu     sync.RWMutex
+	lists  []*Wordlist
+	byName = make(map[string]*Wordlist)
+)
+
+// Register makes a wordlist available by its name and aliases.
+//
+// Names and aliases are case-insensitive. If Size is zero and the Generator has a
+// Len method, like *passit.Wordlist, Size is set from it. If BitsPerWord is zero
+// and Size is known, BitsPerWord is set to the base-2 logarithm of Size.
+//
+// Register panics if the name is empty, if Generator is nil, or if the name or any
+// alias is already registered.
+func Register(wl Wordlist) {
+	if wl.Name == "" {
+		panic("wordlists: Register wordlist name is empty")
+	}
+	if wl.Generator == nil {
+		panic("wordlists: Register generator is nil for " + wl.Name)
+	}
+
+	wl.Aliases = slices.Clone(wl.Aliases)
+	if l, ok := wl.Generator.(interface{ Len() int }); ok && wl.Size == 0 {
+		wl.Size = l.Len()
+	}
+	if wl.BitsPerWord == 0 && wl.Size > 0 {
+		wl.BitsPerWord = math.Log2(float64(wl.Size))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	names := append([]string{wl.Name}, wl.Aliases...)
+	for i, name := range names {
+		name = strings.ToLower(name)
+		if _, dup := byName[name]; dup || slices.Contains(names[:i], name) {
+			panic("wordlists: Register called twice for " + name)
+		}
+		names[i] = name
+	}
+
+	lists = append(lists, &wl)
+	for _, name := range names {
+		byName[name] = &wl
+	}
+}
+
+// Lookup returns the wordlist registered with the given name or alias. The name is
+// case-insensitive.
+func Lookup(name string) (Wordlist, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	wl, ok := byName[strings.ToLower(name)]
+	if !ok {
+		return Wordlist{}, false
+	}
+	return wl.clone(), true
+}
+
+// Generator returns the Generator of the wordlist registered with the given name
+// or alias. It returns nil if the name is unknown.
+func Generator(name string) passit.Generator {
+	wl, ok := Lookup(name)
+	if !ok {
+		return nil
+	}
+	return wl.Generator
+}
+
+// All returns every registered wordlist in the order it was registered.
+func All() []Wordlist {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Wordlist, len(lists))
+	for i, wl := range lists {
+		all[i] = wl.clone()
+	}
+	return all
+}
+
+// Names returns the canonical names of every registered wordlist in the order it
+// was registered.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, len(lists))
+	for i, wl := range lists {
+		names[i] = wl.Name
+	}
+	return names
+}
+
+func (wl *Wordlist) clone() Wordlist {
+	c := *wl
+	c.Aliases = slices.Clone(wl.Aliases)
+	return c
+}
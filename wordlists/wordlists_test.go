@@ -0,0 +1,103 @@
+package wordlists
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.tmthrgd.dev/passit"
+)
+
+func TestBuiltin(t *testing.T) {
+	assert.Equal(t, []string{
+		"orchard:medium", "orchard:long", "orchard:alpha", "orchard:qwerty",
+		"sts10",
+		"eff:large", "eff:short1", "eff:short2",
+		"bip39:english", "bip39:french", "bip39:french-ascii",
+		"bip39:spanish", "bip39:spanish-ascii", "bip39:italian",
+		"lexicon:adjective", "lexicon:noun", "lexicon:verb", "lexicon:adverb",
+		"emoji:13", "emoji:15",
+	}, Names()[:20])
+
+	for _, wl := range All() {
+		wa, err := passit.AnalyzeWordlist(wl.Generator)
+		if !assert.NoErrorf(t, err, "AnalyzeWordlist: %s", wl.Name) {
+			continue
+		}
+
+		assert.Equalf(t, wa.Len, wl.Size, "Size: %s", wl.Name)
+		assert.InDeltaf(t, math.Log2(float64(wa.Len)), wl.BitsPerWord, 1e-9, "BitsPerWord: %s", wl.Name)
+		assert.NotEmptyf(t, wl.Description, "Description: %s", wl.Name)
+		assert.NotEmptyf(t, wl.Source, "Source: %s", wl.Name)
+		assert.NotContainsf(t, wl.Source, "/blob/master/", "Source is not pinned: %s", wl.Name)
+		assert.NotEmptyf(t, wl.License, "License: %s", wl.Name)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		gen  passit.Generator
+	}{
+		{"orchard:long", passit.OrchardStreetLongV2},
+		{"orchard", passit.OrchardStreetLongV2},
+		{"eff", passit.EFFLargeWordlist},
+		{"EFF:Short2", passit.EFFShortWordlist2},
+		{"sts10", passit.STS10Wordlist},
+		{"bip39", passit.BIP39English},
+		{"emoji", passit.Emoji15},
+	} {
+		assert.Samef(t, tc.gen, Generator(tc.name), "Generator(%q)", tc.name)
+	}
+
+	wl, ok := Lookup("eff")
+	require.True(t, ok)
+	assert.Equal(t, "eff:large", wl.Name)
+
+	wl.Aliases[0] = "changed"
+	wl, _ = Lookup("eff")
+	assert.Equal(t, []string{"eff"}, wl.Aliases, "Aliases must be copied")
+
+	_, ok = Lookup("unknown")
+	assert.False(t, ok)
+	assert.Nil(t, Generator("unknown"))
+}
+
+func TestRegister(t *testing.T) {
+	custom, err := passit.NewWordlist(strings.NewReader("alpha\nbravo\ncharlie\ndelta\n"))
+	require.NoError(t, err)
+
+	Register(Wordlist{
+		Name:      "test:custom",
+		Aliases:   []string{"Test:Alias"},
+		Generator: custom,
+	})
+
+	wl, ok := Lookup("TEST:CUSTOM")
+	require.True(t, ok)
+	assert.Equal(t, 4, wl.Size)
+	assert.Equal(t, 2.0, wl.BitsPerWord)
+	assert.Same(t, custom, Generator("test:alias"))
+	assert.Equal(t, "test:custom", Names()[len(Names())-1])
+
+	Register(Wordlist{Name: "test:slice", Generator: passit.FromSlice("a", "b")})
+	wl, _ = Lookup("test:slice")
+	assert.Zero(t, wl.Size, "Size is unknown")
+	assert.Zero(t, wl.BitsPerWord, "BitsPerWord is unknown")
+
+	assert.PanicsWithValue(t, "wordlists: Register wordlist name is empty", func() {
+		Register(Wordlist{Generator: custom})
+	})
+	assert.PanicsWithValue(t, "wordlists: Register generator is nil for test:nil", func() {
+		Register(Wordlist{Name: "test:nil"})
+	})
+	assert.PanicsWithValue(t, "wordlists: Register called twice for eff", func() {
+		Register(Wordlist{Name: "test:dup", Aliases: []string{"EFF"}, Generator: custom})
+	})
+	assert.PanicsWithValue(t, "wordlists: Register called twice for test:self", func() {
+		Register(Wordlist{Name: "test:self", Aliases: []string{"test:self"}, Generator: custom})
+	})
+	assert.Nil(t, Generator("test:dup"), "failed registration must not be visible")
+}
@@ -0,0 +1,216 @@
+package passit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MatchKind describes how a typed word was matched against a wordlist.
+type MatchKind int
+
+const (
+	// MatchNone means the typed word couldn't be resolved to a single word.
+	MatchNone MatchKind = iota
+	// MatchExact means the typed word is in the wordlist.
+	MatchExact
+	// MatchPrefix means the typed word is a prefix of exactly one word in the
+	// wordlist.
+	MatchPrefix
+	// MatchCorrected means exactly one word in the wordlist is nearest to the
+	// typed word by edit distance.
+	MatchCorrected
+)
+
+// WordMatch is the result of matching a typed word against a wordlist.
+type WordMatch struct {
+	// Input is the word as it was typed.
+	Input string
+
+	// Word is the word in the wordlist that Input was resolved to. It is empty
+	// if Kind is MatchNone.
+	Word string
+
+	// Kind describes how Input was resolved to Word.
+	Kind MatchKind
+
+	// Distance is the edit distance between Input and Word if Kind is
+	// MatchCorrected.
+	Distance int
+
+	// Suggestions is a sorted list of candidate words if Kind is MatchNone.
+	// It contains the words that Input is a prefix of and the words nearest to
+	// Input by edit distance.
+	Suggestions []string
+}
+
+// WordMatcher resolves abbreviated or mistyped words to the words of a wordlist.
+//
+// Many wordlists, like EFFShortWordlist2 and OrchardStreetAlphaV2, are designed so
+// that the first few characters of each word are unique. A WordMatcher expands
+// such prefixes to the full word, and suggests or corrects words that are within a
+// small edit distance of a word in the wordlist.
+//
+// Words are compared after normalisation to Unicode Normalization Form C (NFC).
+// Matching is case-sensitive.
+type WordMatcher struct {
+	sorted []string
+}
+
+// NewWordMatcher returns a WordMatcher for the words of gen.
+//
+// gen must be a wordlist accepted by AnalyzeWordlist.
+func NewWordMatcher(gen Generator) (*WordMatcher, error) {
+	wg, ok := gen.(wordlistGenerator)
+	if !ok {
+		return nil, errors.New("passit: generator is not a wordlist")
+	}
+
+	sorted := slices.Clone(wg.wordlist())
+	slices.Sort(sorted)
+	return &WordMatcher{slices.Compact(sorted)}, nil
+}
+
+// Match resolves input to a word in the wordlist.
+//
+// If input is in the wordlist, it is returned unchanged. Otherwise, if input is a
+// prefix of exactly one word, that word is returned. Otherwise, if exactly one word
+// is nearest to input with an edit distance no greater than maxDistance, that word
+// is returned. The edit distance counts insertions, deletions, substitutions and
+// transpositions of adjacent characters.
+//
+// If input can't be resolved, the returned WordMatch has a Kind of MatchNone and
+// lists possible words in Suggestions.
+func (wm *WordMatcher) Match(input string, maxDistance int) WordMatch {
+	m := WordMatch{Input: input}
+	word := norm.NFC.String(strings.TrimSpace(input))
+	if word == "" {
+		return m
+	}
+
+	i, found := slices.BinarySearch(wm.sorted, word)
+	if found {
+		m.Word, m.Kind = word, MatchExact
+		return m
+	}
+
+	var prefixed []string
+	for ; i < len(wm.sorted) && strings.HasPrefix(wm.sorted[i], word); i++ {
+		prefixed = append(prefixed, wm.sorted[i])
+	}
+	if len(prefixed) == 1 {
+		m.Word, m.Kind = prefixed[0], MatchPrefix
+		return m
+	}
+
+	nearest, dist := wm.nearest(word, maxDistance)
+	if len(nearest) == 1 && len(prefixed) == 0 {
+		m.Word, m.Kind, m.Distance = nearest[0], MatchCorrected, dist
+		return m
+	}
+
+	suggestions := append(prefixed, nearest...)
+	slices.Sort(suggestions)
+	m.Suggestions = slices.Compact(suggestions)
+	return m
+}
+
+// nearest returns the words with the smallest edit distance to word, provided it
+// is no greater than maxDistance.
+func (wm *WordMatcher) nearest(word string, maxDistance int) ([]string, int) {
+	if maxDistance <= 0 {
+		return nil, 0
+	}
+
+	a := []rune(word)
+
+	var (
+		nearest []string
+		best    = maxDistance
+	)
+	for _, candidate := range wm.sorted {
+		// The edit distance is at least the difference in length.
+		if diff := utf8.RuneCountInString(candidate) - len(a); diff > best || -diff > best {
+			continue
+		}
+
+		switch dist := editDistance(a, []rune(candidate)); {
+		case dist < best:
+			best = dist
+			nearest = append(nearest[:0], candidate)
+		case dist == best:
+			nearest = append(nearest, candidate)
+		}
+	}
+
+	return nearest, best
+}
+
+// MatchPassphrase resolves each word of pass, separated by sep, to a word in the
+// wordlist as if by Match. If sep consists only of whitespace, words are separated
+// by any run of whitespace.
+//
+// It returns the corrected passphrase with the resolved words joined by sep, along
+// with the WordMatch for each word. If any word can't be resolved, it returns an
+// error identifying the first such word along with the matches.
+func (wm *WordMatcher) MatchPassphrase(pass, sep string, maxDistance int) (string, []WordMatch, error) {
+	var parts []string
+	if strings.TrimSpace(sep) == "" {
+		parts = strings.Fields(pass)
+	} else {
+		parts = strings.Split(strings.TrimSpace(pass), sep)
+	}
+
+	matches := make([]WordMatch, len(parts))
+	words := make([]string, len(parts))
+	var err error
+	for i, part := range parts {
+		matches[i] = wm.Match(part, maxDistance)
+		words[i] = matches[i].Word
+
+		if matches[i].Kind == MatchNone && err == nil {
+			err = fmt.Errorf("passit: word %d %q is not in the wordlist", i+1, part)
+		}
+	}
+	if err != nil {
+		return "", matches, err
+	}
+
+	return strings.Join(words, sep), matches, nil
+}
+
+// editDistance returns the optimal string alignment distance between a and b.
+// This is the Levenshtein distance extended to count the transposition of two
+// adjacent runes as a single edit.
+func editDistance(a, b []rune) int {
+	// Only three rows of the matrix are needed at any one time.
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,91 @@
+package passit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordMatcher(t *testing.T) {
+	_, err := NewWordMatcher(Digit)
+	assert.EqualError(t, err, "passit: generator is not a wordlist")
+
+	wm, err := NewWordMatcher(EFFShortWordlist2)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		input  string
+		expect WordMatch
+	}{
+		{"aardvark", WordMatch{Input: "aardvark", Word: "aardvark", Kind: MatchExact}},
+		{" aardvark\t", WordMatch{Input: " aardvark\t", Word: "aardvark", Kind: MatchExact}},
+		{"aard", WordMatch{Input: "aard", Word: "aardvark", Kind: MatchPrefix}},
+		{"podi", WordMatch{Input: "podi", Word: "podiatrist", Kind: MatchPrefix}},
+		{"aadrvark", WordMatch{Input: "aadrvark", Word: "aardvark", Kind: MatchCorrected, Distance: 1}},
+		{"vegetabel", WordMatch{Input: "vegetabel", Word: "vegetable", Kind: MatchCorrected, Distance: 1}},
+		{"satchell", WordMatch{Input: "satchell", Word: "satchel", Kind: MatchCorrected, Distance: 1}},
+		{"ab", WordMatch{Input: "ab", Suggestions: []string{"abandoned", "abbreviate", "abdomen", "abhorrence", "abiding", "abnormal", "abrasion", "absorbing", "abundant", "abyss", "oat"}}},
+		{"xyzzy", WordMatch{Input: "xyzzy"}},
+		{"", WordMatch{}},
+	} {
+		assert.Equalf(t, tc.expect, wm.Match(tc.input, 2), "Match(%q)", tc.input)
+	}
+
+	assert.Equal(t, WordMatch{Input: "aadrvark"}, wm.Match("aadrvark", 0), "Match with zero maxDistance")
+
+	wm, err = NewWordMatcher(FromSlice("cat", "cot", "dog"))
+	require.NoError(t, err)
+	assert.Equal(t, WordMatch{Input: "cut", Suggestions: []string{"cat", "cot"}}, wm.Match("cut", 1))
+	assert.Equal(t, WordMatch{Input: "dgo", Word: "dog", Kind: MatchCorrected, Distance: 1}, wm.Match("dgo", 1))
+}
+
+func TestWordMatcherPassphrase(t *testing.T) {
+	wm, err := NewWordMatcher(EFFShortWordlist2)
+	require.NoError(t, err)
+
+	tr := newTestRand()
+
+	pass, err := Repeat(EFFShortWordlist2, " ", 8).Password(tr)
+	require.NoError(t, err)
+	require.Equal(t, "barracuda vegetable idly podiatrist bossiness satchel hexagon boxlike", pass)
+
+	for _, tc := range []struct{ input, sep, expect string }{
+		{pass, " ", pass},
+		{"barracuda  vegetable idly podiatrist bossiness satchel hexagon boxlike\n", " ", pass},
+		{"bar veg idl pod bos sat hex box", " ", pass},
+		{"barracdua-vegetable-idyl-pod-bossiness-satchell-hexagon-box", "-", "barracuda-vegetable-idly-podiatrist-bossiness-satchel-hexagon-boxlike"},
+	} {
+		got, matches, err := wm.MatchPassphrase(tc.input, tc.sep, 1)
+		if assert.NoErrorf(t, err, "MatchPassphrase(%q)", tc.input) {
+			assert.Equalf(t, tc.expect, got, "MatchPassphrase(%q)", tc.input)
+			assert.Lenf(t, matches, 8, "MatchPassphrase(%q)", tc.input)
+		}
+	}
+
+	_, matches, err := wm.MatchPassphrase("barracuda vegetable qqqqqq podiatrist", " ", 1)
+	assert.EqualError(t, err, `passit: word 3 "qqqqqq" is not in the wordlist`)
+	assert.Len(t, matches, 4)
+	assert.Equal(t, MatchNone, matches[2].Kind)
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tc := range []struct {
+		a, b   string
+		expect int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"ab", "ba", 1},
+		{"abcd", "acbd", 1},
+		{"ca", "abc", 3},
+		{"café", "cafe", 1},
+		{"naïve", "naive", 1},
+	} {
+		assert.Equalf(t, tc.expect, editDistance([]rune(tc.a), []rune(tc.b)), "editDistance(%q, %q)", tc.a, tc.b)
+		assert.Equalf(t, tc.expect, editDistance([]rune(tc.b), []rune(tc.a)), "editDistance(%q, %q)", tc.b, tc.a)
+	}
+}